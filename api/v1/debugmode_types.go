@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -14,16 +16,141 @@ const (
 	DebugModeStatusFailed          StatusPhase = "Failed"
 )
 
+// Log levels that can be set for dogus and components.
+const (
+	LogLevelError = "ERROR"
+	LogLevelWarn  = "WARN"
+	LogLevelInfo  = "INFO"
+	LogLevelDebug = "DEBUG"
+)
+
+// LogLevels contains all log levels that can be set for dogus and components.
+var LogLevels = []string{LogLevelError, LogLevelWarn, LogLevelInfo, LogLevelDebug}
+
+// TargetKind defines which kind of ecosystem resource a log level target refers to.
+// +kubebuilder:validation:Enum=dogu;component
+type TargetKind string
+
+const (
+	TargetKindDogu      TargetKind = "dogu"
+	TargetKindComponent TargetKind = "component"
+)
+
+// LogLevelTarget overrides the global TargetLogLevel for a single dogu or component.
+type LogLevelTarget struct {
+	// Kind defines whether the target is a dogu or a component.
+	Kind TargetKind `json:"kind"`
+	// Name is the simple name of the dogu or component, e.g. "cas" or "k8s-blueprint-operator".
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// LogLevel is the log level that should be set for this target.
+	// +kubebuilder:validation:MinLength=1
+	LogLevel string `json:"logLevel"`
+}
+
+// Key returns the key of the target in the form "<kind>/<name>", e.g. "dogu/cas".
+func (t LogLevelTarget) Key() string {
+	return string(t.Kind) + "/" + t.Name
+}
+
 // DebugModeSpec defines the desired state of DebugMode
 type DebugModeSpec struct {
 	DeactivateTimestamp metav1.Time `json:"deactivateTimestamp,omitempty"`
-	TargetLogLevel      string      `json:"targetLogLevel,omitempty"`
+	// Duration defines how long the debug mode should stay active, counted from the creation of the resource.
+	// In contrast to DeactivateTimestamp it is resolved against the server time and is thereby robust against
+	// clock skew between client and cluster. DeactivateTimestamp takes precedence if both are set.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// TargetLogLevel is the log level set for all dogus and components that are not listed in Targets.
+	// If it is empty, only the log levels of the Targets are changed and all others are left unchanged.
+	// +optional
+	TargetLogLevel string `json:"targetLogLevel,omitempty"`
+	// Targets override the TargetLogLevel for single dogus or components.
+	// +optional
+	// +listType=map
+	// +listMapKey=kind
+	// +listMapKey=name
+	Targets []LogLevelTarget `json:"targets,omitempty"`
+}
+
+// LogLevelFor returns the log level that should be set for the given target.
+// It returns the level of a matching entry in Targets and falls back to TargetLogLevel otherwise.
+// An empty result means that the log level of the target should be left unchanged, which is the case for targets
+// not listed in Targets if TargetLogLevel is empty.
+func (s DebugModeSpec) LogLevelFor(kind TargetKind, name string) string {
+	for _, target := range s.Targets {
+		if target.Kind == kind && target.Name == name {
+			return target.LogLevel
+		}
+	}
+
+	return s.TargetLogLevel
 }
 
 const (
 	ConditionLogLevelSet string = "LogLevelsSet"
+	// ConditionReady is true if the log levels of all targets are set and the debug mode is active.
+	ConditionReady string = "Ready"
+	// ConditionRollbackCompleted is true if the original log levels of all targets were restored.
+	ConditionRollbackCompleted string = "RollbackCompleted"
+	// ConditionDeactivationScheduled is true if the deactivation of the debug mode is scheduled.
+	ConditionDeactivationScheduled string = "DeactivationScheduled"
+	// ConditionDegraded is true if the log levels of some targets could not be changed.
+	ConditionDegraded string = "Degraded"
+)
+
+// MaxErrorEntries is the maximum number of ErrorEntries kept in the status. Older entries are dropped first.
+// It must match the MaxItems validation of DebugModeStatus.ErrorEntries.
+const MaxErrorEntries = 20
+
+// ErrorEntry describes a single error that occurred while handling the debug mode.
+type ErrorEntry struct {
+	// Target is the key of the dogu or component the error occurred for as returned by LogLevelTarget.Key, e.g. "dogu/cas".
+	// Keys of the state ConfigMap like "dogu_cas" are converted with state.TargetKey.
+	// It is empty if the error does not belong to a single target.
+	// +optional
+	Target string `json:"target,omitempty"`
+	// Phase is the phase the debug mode was in when the error occurred.
+	// +optional
+	Phase StatusPhase `json:"phase,omitempty"`
+	// Timestamp is the point in time when the error occurred.
+	Timestamp metav1.Time `json:"timestamp"`
+	// Message describes the error.
+	Message string `json:"message"`
+	// Retryable is true if the failed operation may succeed when it is retried.
+	// +optional
+	Retryable bool `json:"retryable,omitempty"`
+}
+
+// TargetState describes how far the log level change of a single target has progressed.
+// +kubebuilder:validation:Enum=Pending;Applied;RolledBack;Failed
+type TargetState string
+
+const (
+	TargetStatePending    TargetState = "Pending"
+	TargetStateApplied    TargetState = "Applied"
+	TargetStateRolledBack TargetState = "RolledBack"
+	TargetStateFailed     TargetState = "Failed"
 )
 
+// TargetStatus describes the log level change of a single dogu or component.
+type TargetStatus struct {
+	// Kind defines whether the target is a dogu or a component.
+	Kind TargetKind `json:"kind"`
+	// Name is the simple name of the dogu or component.
+	Name string `json:"name"`
+	// OriginalLogLevel is the log level the target had before the debug mode was activated.
+	// +optional
+	OriginalLogLevel string `json:"originalLogLevel,omitempty"`
+	// AppliedLogLevel is the log level that was set for the target by the debug mode.
+	// +optional
+	AppliedLogLevel string `json:"appliedLogLevel,omitempty"`
+	// State describes how far the log level change of the target has progressed.
+	State TargetState `json:"state"`
+	// LastTransitionTime is the last time the State changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
 // DebugModeStatus defines the observed state of DebugMode.
 type DebugModeStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -31,11 +158,70 @@ type DebugModeStatus struct {
 	// Phase defines the current general state the resource is in.
 	Phase StatusPhase `json:"phase,omitempty"`
 	// Errors contains error messages that accumulated during execution.
+	//
+	// Deprecated: Use ErrorEntries instead, which tell which target failed and when.
 	Errors string `json:"errors,omitempty"`
+	// ErrorEntries contains the latest errors that occurred during execution, the oldest first.
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	ErrorEntries []ErrorEntry `json:"errorEntries,omitempty"`
 	// Conditions are used to influence the Phase
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// DeactivationTime is the resolved point in time when the debug mode will be deactivated.
+	// +optional
+	DeactivationTime *metav1.Time `json:"deactivationTime,omitempty"`
+	// Targets contains the progress of the log level change of each dogu and component.
+	// +optional
+	// +listType=map
+	// +listMapKey=kind
+	// +listMapKey=name
+	Targets []TargetStatus `json:"targets,omitempty"`
+}
+
+// FindTargetStatus returns the status of the given target or nil if the target has no status yet.
+func (s *DebugModeStatus) FindTargetStatus(kind TargetKind, name string) *TargetStatus {
+	for i := range s.Targets {
+		if s.Targets[i].Kind == kind && s.Targets[i].Name == name {
+			return &s.Targets[i]
+		}
+	}
+
+	return nil
+}
+
+// SetTargetStatus adds the status of a target or updates the existing status of the same target.
+// Like meta.SetStatusCondition, the LastTransitionTime is only changed if the State changes
+// and is set to now if it is not given.
+func (s *DebugModeStatus) SetTargetStatus(targetStatus TargetStatus) {
+	if targetStatus.LastTransitionTime.IsZero() {
+		targetStatus.LastTransitionTime = metav1.Now()
+	}
+
+	existing := s.FindTargetStatus(targetStatus.Kind, targetStatus.Name)
+	if existing == nil {
+		s.Targets = append(s.Targets, targetStatus)
+		return
+	}
+
+	if existing.State == targetStatus.State {
+		targetStatus.LastTransitionTime = existing.LastTransitionTime
+	}
+	*existing = targetStatus
 }
 
+const (
+	// SingletonName is the only name a DebugMode may have, as enforced by the validation rule of the CRD.
+	// The rule cannot reference this constant, so a test ensures that both stay the same.
+	SingletonName = "debug-mode"
+	// SingletonNameViolationMessage is the message of the validation rule of the CRD that enforces the SingletonName.
+	SingletonNameViolationMessage = "Name of DebugMode singleton must always be '" + SingletonName + "'"
+)
+
+// DeactivationTimeNotInFutureMessage is the detail of the validation error returned by the validating webhook if
+// the deactivation time of a DebugMode is not in the future.
+const DeactivationTimeNotInFutureMessage = "deactivation time must be in the future"
+
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:validation:XValidation:rule="self.metadata.name == 'debug-mode'",message="Name of DebugMode singleton must always be 'debug-mode'"
@@ -55,6 +241,45 @@ type DebugMode struct {
 	Status DebugModeStatus `json:"status,omitempty,omitzero"`
 }
 
+// AppendError adds the entry to the ErrorEntries and drops the oldest entries exceeding MaxErrorEntries.
+func (s *DebugModeStatus) AppendError(entry ErrorEntry) {
+	s.ErrorEntries = append(s.ErrorEntries, entry)
+	if len(s.ErrorEntries) > MaxErrorEntries {
+		s.ErrorEntries = s.ErrorEntries[len(s.ErrorEntries)-MaxErrorEntries:]
+	}
+}
+
+// EffectiveDeactivationTime returns the point in time when the debug mode should be deactivated.
+// An explicit Spec.DeactivateTimestamp wins over Spec.Duration, which is resolved against the creation timestamp
+// set by the API server. If neither can be resolved, the already resolved Status.DeactivationTime is returned.
+// The returned time is zero if no deactivation time is known at all.
+func (d *DebugMode) EffectiveDeactivationTime() metav1.Time {
+	if !d.Spec.DeactivateTimestamp.IsZero() {
+		return d.Spec.DeactivateTimestamp
+	}
+
+	if d.Spec.Duration != nil && !d.CreationTimestamp.IsZero() {
+		return metav1.NewTime(d.CreationTimestamp.Add(d.Spec.Duration.Duration))
+	}
+
+	if d.Status.DeactivationTime != nil {
+		return *d.Status.DeactivationTime
+	}
+
+	return metav1.Time{}
+}
+
+// RemainingDuration returns how long the debug mode stays active, measured from now.
+// It returns zero if the deactivation time has already passed or is not known.
+func (d *DebugMode) RemainingDuration(now time.Time) time.Duration {
+	deactivationTime := d.EffectiveDeactivationTime()
+	if deactivationTime.IsZero() || !deactivationTime.After(now) {
+		return 0
+	}
+
+	return deactivationTime.Sub(now)
+}
+
 // +kubebuilder:object:root=true
 
 // DebugModeList contains a list of DebugMode
@@ -0,0 +1,176 @@
+package v1
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLogLevelTarget_Key(t *testing.T) {
+	assert.Equal(t, "dogu/cas", LogLevelTarget{Kind: TargetKindDogu, Name: "cas", LogLevel: "DEBUG"}.Key())
+	assert.Equal(t, "component/k8s-blueprint-operator", LogLevelTarget{Kind: TargetKindComponent, Name: "k8s-blueprint-operator"}.Key())
+}
+
+func TestDebugModeSpec_LogLevelFor(t *testing.T) {
+	spec := DebugModeSpec{
+		TargetLogLevel: "INFO",
+		Targets: []LogLevelTarget{
+			{Kind: TargetKindDogu, Name: "cas", LogLevel: "DEBUG"},
+			{Kind: TargetKindComponent, Name: "k8s-blueprint-operator", LogLevel: "WARN"},
+		},
+	}
+
+	t.Run("should return level of matching target", func(t *testing.T) {
+		assert.Equal(t, "DEBUG", spec.LogLevelFor(TargetKindDogu, "cas"))
+		assert.Equal(t, "WARN", spec.LogLevelFor(TargetKindComponent, "k8s-blueprint-operator"))
+	})
+	t.Run("should fall back to global level if no target matches", func(t *testing.T) {
+		assert.Equal(t, "INFO", spec.LogLevelFor(TargetKindDogu, "ldap"))
+	})
+	t.Run("should not match target with same name but different kind", func(t *testing.T) {
+		assert.Equal(t, "INFO", spec.LogLevelFor(TargetKindComponent, "cas"))
+	})
+	t.Run("should return empty level for unlisted target without global level", func(t *testing.T) {
+		targetsOnly := DebugModeSpec{Targets: spec.Targets}
+
+		assert.Equal(t, "DEBUG", targetsOnly.LogLevelFor(TargetKindDogu, "cas"))
+		assert.Empty(t, targetsOnly.LogLevelFor(TargetKindDogu, "ldap"))
+	})
+}
+
+func TestDebugMode_EffectiveDeactivationTime(t *testing.T) {
+	created := metav1.NewTime(time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC))
+	explicit := metav1.NewTime(time.Date(2025, 9, 1, 18, 0, 0, 0, time.UTC))
+	resolved := metav1.NewTime(time.Date(2025, 9, 1, 14, 0, 0, 0, time.UTC))
+
+	t.Run("should prefer explicit deactivate timestamp", func(t *testing.T) {
+		debugMode := &DebugMode{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+			Spec:       DebugModeSpec{DeactivateTimestamp: explicit, Duration: &metav1.Duration{Duration: time.Hour}},
+		}
+
+		assert.Equal(t, explicit, debugMode.EffectiveDeactivationTime())
+	})
+	t.Run("should resolve duration against creation timestamp", func(t *testing.T) {
+		debugMode := &DebugMode{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+			Spec:       DebugModeSpec{Duration: &metav1.Duration{Duration: time.Hour}},
+			Status:     DebugModeStatus{DeactivationTime: &resolved},
+		}
+
+		assert.True(t, created.Add(time.Hour).Equal(debugMode.EffectiveDeactivationTime().Time))
+	})
+	t.Run("should fall back to resolved status", func(t *testing.T) {
+		debugMode := &DebugMode{
+			Spec:   DebugModeSpec{Duration: &metav1.Duration{Duration: time.Hour}},
+			Status: DebugModeStatus{DeactivationTime: &resolved},
+		}
+
+		assert.Equal(t, resolved, debugMode.EffectiveDeactivationTime())
+	})
+	t.Run("should return zero time if nothing is set", func(t *testing.T) {
+		deactivationTime := (&DebugMode{}).EffectiveDeactivationTime()
+		assert.True(t, deactivationTime.IsZero())
+	})
+}
+
+func TestDebugMode_RemainingDuration(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	debugMode := &DebugMode{Spec: DebugModeSpec{DeactivateTimestamp: metav1.NewTime(now.Add(30 * time.Minute))}}
+
+	t.Run("should return time until deactivation", func(t *testing.T) {
+		assert.Equal(t, 30*time.Minute, debugMode.RemainingDuration(now))
+	})
+	t.Run("should return zero if deactivation time has passed", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), debugMode.RemainingDuration(now.Add(time.Hour)))
+	})
+	t.Run("should return zero if deactivation time is unknown", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), (&DebugMode{}).RemainingDuration(now))
+	})
+}
+
+func TestDebugModeStatus_AppendError(t *testing.T) {
+	t.Run("should append entry", func(t *testing.T) {
+		status := &DebugModeStatus{}
+
+		status.AppendError(ErrorEntry{Target: "dogu/cas", Message: "failed"})
+
+		assert.Equal(t, []ErrorEntry{{Target: "dogu/cas", Message: "failed"}}, status.ErrorEntries)
+	})
+	t.Run("should drop oldest entries exceeding the maximum", func(t *testing.T) {
+		status := &DebugModeStatus{}
+
+		for i := 0; i < MaxErrorEntries+2; i++ {
+			status.AppendError(ErrorEntry{Message: fmt.Sprintf("error %d", i)})
+		}
+
+		assert.Len(t, status.ErrorEntries, MaxErrorEntries)
+		assert.Equal(t, "error 2", status.ErrorEntries[0].Message)
+		assert.Equal(t, fmt.Sprintf("error %d", MaxErrorEntries+1), status.ErrorEntries[MaxErrorEntries-1].Message)
+	})
+}
+
+func TestDebugModeStatus_SetTargetStatus(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(time.Date(2025, 9, 1, 13, 0, 0, 0, time.UTC))
+
+	t.Run("should add new target with current time", func(t *testing.T) {
+		status := &DebugModeStatus{}
+
+		status.SetTargetStatus(TargetStatus{Kind: TargetKindDogu, Name: "cas", State: TargetStatePending})
+
+		require.Len(t, status.Targets, 1)
+		assert.Equal(t, TargetStatePending, status.Targets[0].State)
+		assert.False(t, status.Targets[0].LastTransitionTime.IsZero())
+	})
+	t.Run("should update existing target and transition time on state change", func(t *testing.T) {
+		status := &DebugModeStatus{Targets: []TargetStatus{
+			{Kind: TargetKindDogu, Name: "cas", State: TargetStatePending, LastTransitionTime: earlier},
+			{Kind: TargetKindDogu, Name: "ldap", State: TargetStatePending, LastTransitionTime: earlier},
+		}}
+
+		status.SetTargetStatus(TargetStatus{Kind: TargetKindDogu, Name: "cas", OriginalLogLevel: LogLevelWarn, AppliedLogLevel: LogLevelDebug, State: TargetStateApplied, LastTransitionTime: later})
+
+		require.Len(t, status.Targets, 2)
+		assert.Equal(t, TargetStatus{Kind: TargetKindDogu, Name: "cas", OriginalLogLevel: LogLevelWarn, AppliedLogLevel: LogLevelDebug, State: TargetStateApplied, LastTransitionTime: later}, status.Targets[0])
+		assert.Equal(t, TargetStatePending, status.Targets[1].State)
+	})
+	t.Run("should keep transition time if state does not change", func(t *testing.T) {
+		status := &DebugModeStatus{Targets: []TargetStatus{{Kind: TargetKindDogu, Name: "cas", State: TargetStateApplied, LastTransitionTime: earlier}}}
+
+		status.SetTargetStatus(TargetStatus{Kind: TargetKindDogu, Name: "cas", AppliedLogLevel: LogLevelDebug, State: TargetStateApplied, LastTransitionTime: later})
+
+		assert.Equal(t, earlier, status.Targets[0].LastTransitionTime)
+		assert.Equal(t, LogLevelDebug, status.Targets[0].AppliedLogLevel)
+	})
+	t.Run("should not mix up kinds", func(t *testing.T) {
+		status := &DebugModeStatus{Targets: []TargetStatus{{Kind: TargetKindDogu, Name: "cas", State: TargetStateApplied, LastTransitionTime: earlier}}}
+
+		status.SetTargetStatus(TargetStatus{Kind: TargetKindComponent, Name: "cas", State: TargetStatePending})
+
+		assert.Len(t, status.Targets, 2)
+		assert.Nil(t, status.FindTargetStatus(TargetKindComponent, "ldap"))
+		assert.Equal(t, TargetStatePending, status.FindTargetStatus(TargetKindComponent, "cas").State)
+	})
+}
+
+func TestSingletonName_MatchesCRDValidation(t *testing.T) {
+	crdFiles := []string{
+		"../../config/crd/bases/k8s.cloudogu.com_debugmodes.yaml",
+		"../../k8s/helm-crd/templates/k8s.cloudogu.com_debugmodes.yaml",
+	}
+	for _, crdFile := range crdFiles {
+		t.Run(crdFile, func(t *testing.T) {
+			crd, err := os.ReadFile(crdFile)
+			require.NoError(t, err)
+
+			assert.Contains(t, string(crd), fmt.Sprintf("rule: self.metadata.name == '%s'", SingletonName))
+			assert.Contains(t, string(crd), "message: "+SingletonNameViolationMessage)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// The code generators of k8s.io/code-generator read the group name from doc.go only.
+// +groupName=k8s.cloudogu.com
+
+package v1
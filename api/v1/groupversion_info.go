@@ -12,6 +12,9 @@ var (
 	// GroupVersion is group version used to register these objects.
 	GroupVersion = schema.GroupVersion{Group: "k8s.cloudogu.com", Version: "v1"}
 
+	// SchemeGroupVersion is an alias of GroupVersion which is expected by generated code like the apply configurations.
+	SchemeGroupVersion = GroupVersion
+
 	// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
 	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
 
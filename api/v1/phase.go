@@ -0,0 +1,50 @@
+package v1
+
+import "fmt"
+
+// phaseTransitions contains the phases each phase may transition to.
+// Staying in the same phase is always allowed, so it is not listed here.
+// A debug mode that is deleted while active rolls back directly from SetDebugMode, and a debug mode that failed
+// while setting the log levels may still be rolled back to restore the levels that were already changed.
+var phaseTransitions = map[StatusPhase][]StatusPhase{
+	"":                             {DebugModeStatusSet, DebugModeStatusFailed},
+	DebugModeStatusSet:             {DebugModeStatusWaitForRollback, DebugModeStatusRollback, DebugModeStatusFailed},
+	DebugModeStatusWaitForRollback: {DebugModeStatusRollback, DebugModeStatusSet, DebugModeStatusFailed},
+	DebugModeStatusRollback:        {DebugModeStatusCompleted, DebugModeStatusFailed},
+	DebugModeStatusCompleted:       {},
+	DebugModeStatusFailed:          {DebugModeStatusRollback},
+}
+
+// IsTerminal returns true if the debug mode has finished and will not become active again.
+// A failed debug mode may still move to Rollback to restore the log levels that were already changed.
+func (p StatusPhase) IsTerminal() bool {
+	return p == DebugModeStatusCompleted || p == DebugModeStatusFailed
+}
+
+// CanTransition returns true if a debug mode may move from one phase to the other.
+// The empty phase is the phase of a newly created debug mode.
+func CanTransition(from, to StatusPhase) bool {
+	if from == to {
+		return true
+	}
+
+	for _, allowed := range phaseTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IllegalPhaseTransitionError is returned if a debug mode should move to a phase that cannot be reached from its
+// current phase.
+// +kubebuilder:object:generate=false
+type IllegalPhaseTransitionError struct {
+	From StatusPhase
+	To   StatusPhase
+}
+
+func (e *IllegalPhaseTransitionError) Error() string {
+	return fmt.Sprintf("illegal phase transition from %q to %q", e.From, e.To)
+}
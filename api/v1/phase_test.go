@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from StatusPhase
+		to   StatusPhase
+		want bool
+	}{
+		{"", DebugModeStatusSet, true},
+		{"", DebugModeStatusFailed, true},
+		{"", DebugModeStatusRollback, false},
+		{DebugModeStatusSet, DebugModeStatusSet, true},
+		{DebugModeStatusSet, DebugModeStatusWaitForRollback, true},
+		{DebugModeStatusSet, DebugModeStatusRollback, true},
+		{DebugModeStatusSet, DebugModeStatusCompleted, false},
+		{DebugModeStatusWaitForRollback, DebugModeStatusRollback, true},
+		{DebugModeStatusWaitForRollback, DebugModeStatusSet, true},
+		{DebugModeStatusRollback, DebugModeStatusCompleted, true},
+		{DebugModeStatusRollback, DebugModeStatusFailed, true},
+		{DebugModeStatusRollback, DebugModeStatusSet, false},
+		{DebugModeStatusCompleted, DebugModeStatusSet, false},
+		{DebugModeStatusCompleted, DebugModeStatusFailed, false},
+		{DebugModeStatusFailed, DebugModeStatusRollback, true},
+		{DebugModeStatusFailed, DebugModeStatusSet, false},
+		{DebugModeStatusFailed, DebugModeStatusCompleted, false},
+		{"Unknown", DebugModeStatusSet, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, CanTransition(tt.from, tt.to))
+		})
+	}
+}
+
+func TestStatusPhase_IsTerminal(t *testing.T) {
+	assert.True(t, DebugModeStatusCompleted.IsTerminal())
+	assert.True(t, DebugModeStatusFailed.IsTerminal())
+	assert.False(t, DebugModeStatusSet.IsTerminal())
+	assert.False(t, DebugModeStatusWaitForRollback.IsTerminal())
+	assert.False(t, DebugModeStatusRollback.IsTerminal())
+	assert.False(t, StatusPhase("").IsTerminal())
+}
+
+func TestIllegalPhaseTransitionError_Error(t *testing.T) {
+	err := &IllegalPhaseTransitionError{From: DebugModeStatusCompleted, To: DebugModeStatusSet}
+
+	assert.Equal(t, `illegal phase transition from "Completed" to "SetDebugMode"`, err.Error())
+}
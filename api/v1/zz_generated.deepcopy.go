@@ -1,7 +1,7 @@
 //go:build !ignore_autogenerated
 
 /*
-This file was generated with "make generate".
+This file was generated with "make generate-deepcopy".
 */
 
 // Code generated by controller-gen. DO NOT EDIT.
@@ -40,10 +40,52 @@ func (in *DebugMode) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugModeList) DeepCopyInto(out *DebugModeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DebugMode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugModeList.
+func (in *DebugModeList) DeepCopy() *DebugModeList {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugModeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DebugModeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DebugModeSpec) DeepCopyInto(out *DebugModeSpec) {
 	*out = *in
 	in.DeactivateTimestamp.DeepCopyInto(&out.DeactivateTimestamp)
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]LogLevelTarget, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugModeSpec.
@@ -59,6 +101,13 @@ func (in *DebugModeSpec) DeepCopy() *DebugModeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DebugModeStatus) DeepCopyInto(out *DebugModeStatus) {
 	*out = *in
+	if in.ErrorEntries != nil {
+		in, out := &in.ErrorEntries, &out.ErrorEntries
+		*out = make([]ErrorEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -66,6 +115,17 @@ func (in *DebugModeStatus) DeepCopyInto(out *DebugModeStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DeactivationTime != nil {
+		in, out := &in.DeactivationTime, &out.DeactivationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]TargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugModeStatus.
@@ -79,33 +139,48 @@ func (in *DebugModeStatus) DeepCopy() *DebugModeStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DebugModeList) DeepCopyInto(out *DebugModeList) {
+func (in *ErrorEntry) DeepCopyInto(out *ErrorEntry) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]DebugMode, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorEntry.
+func (in *ErrorEntry) DeepCopy() *ErrorEntry {
+	if in == nil {
+		return nil
 	}
+	out := new(ErrorEntry)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugModeList.
-func (in *DebugModeList) DeepCopy() *DebugModeList {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogLevelTarget) DeepCopyInto(out *LogLevelTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogLevelTarget.
+func (in *LogLevelTarget) DeepCopy() *LogLevelTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(DebugModeList)
+	out := new(LogLevelTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DebugModeList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetStatus) DeepCopyInto(out *TargetStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetStatus.
+func (in *TargetStatus) DeepCopy() *TargetStatus {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(TargetStatus)
+	in.DeepCopyInto(out)
+	return out
 }
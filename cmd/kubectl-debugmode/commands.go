@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	clientv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+	"github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/session"
+)
+
+const defaultDebugWindow = time.Hour
+
+func newOnCmd(opts *options) *cobra.Command {
+	var logLevel string
+	var duration time.Duration
+	var wait bool
+
+	cmd := &cobra.Command{
+		Use:   "on",
+		Short: "Activate the debug mode",
+		Example: `  # raise the log level of all dogus and components to DEBUG for two hours
+  kubectl debugmode on --level DEBUG --for 2h`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(v1.LogLevels, logLevel) {
+				return fmt.Errorf("invalid log level %q, must be one of %v", logLevel, v1.LogLevels)
+			}
+			if duration <= 0 {
+				return fmt.Errorf("invalid duration %s, must be positive", duration)
+			}
+
+			debugModes, err := opts.newClient(opts)
+			if err != nil {
+				return err
+			}
+
+			debugMode, err := session.NewSession(debugModes).Activate(cmd.Context(), logLevel, duration)
+			if err != nil {
+				return err
+			}
+
+			if wait {
+				debugMode, err = debugModes.WaitForPhase(cmd.Context(), debugMode.Name, v1.DebugModeStatusSet, v1.DebugModeStatusFailed)
+				if err != nil {
+					return err
+				}
+			}
+
+			return printDebugMode(opts, debugMode)
+		},
+	}
+	cmd.Flags().StringVar(&logLevel, "level", v1.LogLevelDebug, fmt.Sprintf("log level to set, one of %v", v1.LogLevels))
+	cmd.Flags().DurationVar(&duration, "for", defaultDebugWindow, "how long the debug mode stays active")
+	cmd.Flags().BoolVar(&wait, "wait", false, "wait until the log levels are set")
+
+	return cmd
+}
+
+func newExtendCmd(opts *options) *cobra.Command {
+	var extra time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "extend",
+		Short: "Extend the active debug mode",
+		Example: `  # keep the debug mode active for another 30 minutes
+  kubectl debugmode extend --for 30m`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if extra <= 0 {
+				return fmt.Errorf("invalid duration %s, must be positive", extra)
+			}
+
+			debugModes, err := opts.newClient(opts)
+			if err != nil {
+				return err
+			}
+
+			debugMode, err := session.NewSession(debugModes).Extend(cmd.Context(), extra)
+			if err != nil {
+				return err
+			}
+
+			return printDebugMode(opts, debugMode)
+		},
+	}
+	cmd.Flags().DurationVar(&extra, "for", defaultDebugWindow, "how long the debug mode is extended")
+
+	return cmd
+}
+
+func newOffCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "off",
+		Short: "Deactivate the debug mode and restore the original log levels",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			debugModes, err := opts.newClient(opts)
+			if err != nil {
+				return err
+			}
+
+			err = session.NewSession(debugModes).Deactivate(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(opts.out, "debug mode deactivated, the original log levels are being restored")
+			return err
+		},
+	}
+}
+
+func newStatusCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show phase, remaining time, conditions and log levels of the debug mode",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			debugModes, err := opts.newClient(opts)
+			if err != nil {
+				return err
+			}
+
+			debugMode, err := debugModes.GetSingleton(cmd.Context(), metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				_, err = fmt.Fprintln(opts.out, "debug mode is not active")
+				return err
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get debug mode: %w", err)
+			}
+
+			return printDebugMode(opts, debugMode)
+		},
+	}
+}
+
+func newWatchCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch",
+		Short: "Print every change of the debug mode until it is completed, failed or deleted",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			debugModes, err := opts.newClient(opts)
+			if err != nil {
+				return err
+			}
+
+			return watchDebugMode(cmd.Context(), debugModes, newWatchPrinter(opts))
+		},
+	}
+}
+
+// watchDebugMode prints the debug mode and every change until it reaches a terminal phase or is deleted.
+func watchDebugMode(ctx context.Context, debugModes clientv1.DebugModeInterface, printer *watchPrinter) error {
+	observed := false
+	_, err := debugModes.WaitFor(ctx, v1.SingletonName, func(debugMode *v1.DebugMode) (bool, error) {
+		observed = true
+		return debugMode.Status.Phase.IsTerminal(), printer.print(debugMode)
+	})
+	if observed && errors.Is(err, clientv1.ErrDebugModeNotFound) {
+		return printer.printDeleted()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to watch debug mode: %w", err)
+	}
+
+	return nil
+}
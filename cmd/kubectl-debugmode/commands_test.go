@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/fake"
+	clientv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+)
+
+var testCtx = context.Background()
+
+var testNow = time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestDebugMode(phase v1.StatusPhase) *v1.DebugMode {
+	return &v1.DebugMode{
+		ObjectMeta: metav1.ObjectMeta{Name: v1.SingletonName, Namespace: "ecosystem", ResourceVersion: "1", CreationTimestamp: metav1.NewTime(testNow.Add(-time.Hour))},
+		Spec:       v1.DebugModeSpec{TargetLogLevel: v1.LogLevelDebug, DeactivateTimestamp: metav1.NewTime(testNow.Add(90 * time.Minute))},
+		Status:     v1.DebugModeStatus{Phase: phase},
+	}
+}
+
+func runCmd(t *testing.T, clientSet *fake.Clientset, args ...string) (string, error) {
+	t.Helper()
+
+	out := &bytes.Buffer{}
+	opts := &options{
+		out: out,
+		now: func() time.Time { return testNow },
+		newClient: func(opts *options) (clientv1.DebugModeInterface, error) {
+			return clientSet.DebugModeV1().DebugMode(opts.namespace), nil
+		},
+	}
+
+	rootCmd := newRootCmd(opts)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs(append(args, "--namespace", "ecosystem"))
+	err := rootCmd.ExecuteContext(testCtx)
+
+	return out.String(), err
+}
+
+func TestOnCmd(t *testing.T) {
+	t.Run("should activate debug mode", func(t *testing.T) {
+		// given
+		clientSet := fake.NewSimpleClientset()
+
+		// when
+		out, err := runCmd(t, clientSet, "on", "--level", "INFO", "--for", "2h")
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, out, "Log level:     INFO")
+		debugMode, err := clientSet.DebugModeV1().DebugMode("ecosystem").GetSingleton(testCtx, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, v1.LogLevelInfo, debugMode.Spec.TargetLogLevel)
+		assert.Equal(t, 2*time.Hour, debugMode.Spec.Duration.Duration)
+	})
+	t.Run("should fail if debug mode is already active", func(t *testing.T) {
+		// given
+		clientSet := fake.NewSimpleClientset(newTestDebugMode(v1.DebugModeStatusSet))
+
+		// when
+		_, err := runCmd(t, clientSet, "on")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "debug mode is already active")
+	})
+	t.Run("should fail on invalid log level", func(t *testing.T) {
+		// when
+		_, err := runCmd(t, fake.NewSimpleClientset(), "on", "--level", "TRACE")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid log level \"TRACE\"")
+	})
+	t.Run("should fail on invalid duration", func(t *testing.T) {
+		// when
+		_, err := runCmd(t, fake.NewSimpleClientset(), "on", "--for", "-1h")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid duration -1h0m0s, must be positive")
+	})
+}
+
+func TestExtendCmd(t *testing.T) {
+	t.Run("should extend debug mode", func(t *testing.T) {
+		// given
+		// the session measures the extension with the real clock
+		deactivationTime := time.Now().Add(time.Hour).Truncate(time.Second)
+		debugMode := newTestDebugMode(v1.DebugModeStatusSet)
+		debugMode.Spec.DeactivateTimestamp = metav1.NewTime(deactivationTime)
+		clientSet := fake.NewSimpleClientset(debugMode)
+
+		// when
+		_, err := runCmd(t, clientSet, "extend", "--for", "30m")
+
+		// then
+		require.NoError(t, err)
+		debugMode, err = clientSet.DebugModeV1().DebugMode("ecosystem").GetSingleton(testCtx, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.True(t, deactivationTime.Add(30*time.Minute).Equal(debugMode.Spec.DeactivateTimestamp.Time))
+	})
+	t.Run("should fail if debug mode is not active", func(t *testing.T) {
+		// when
+		_, err := runCmd(t, fake.NewSimpleClientset(), "extend")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "debug mode is not active")
+	})
+}
+
+func TestOffCmd(t *testing.T) {
+	// given
+	clientSet := fake.NewSimpleClientset(newTestDebugMode(v1.DebugModeStatusSet))
+
+	// when
+	out, err := runCmd(t, clientSet, "off")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "debug mode deactivated, the original log levels are being restored\n", out)
+	_, err = clientSet.DebugModeV1().DebugMode("ecosystem").GetSingleton(testCtx, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestStatusCmd(t *testing.T) {
+	debugMode := newTestDebugMode(v1.DebugModeStatusSet)
+	debugMode.Status.Conditions = []metav1.Condition{{Type: v1.ConditionLogLevelSet, Status: metav1.ConditionTrue, Reason: "LogLevelsSet", Message: "all log levels set"}}
+	debugMode.Status.Targets = []v1.TargetStatus{{Kind: v1.TargetKindDogu, Name: "cas", OriginalLogLevel: v1.LogLevelWarn, AppliedLogLevel: v1.LogLevelDebug, State: v1.TargetStateApplied}}
+
+	t.Run("should print table", func(t *testing.T) {
+		// when
+		out, err := runCmd(t, fake.NewSimpleClientset(debugMode), "status")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, `Name:          debug-mode
+Phase:         SetDebugMode
+Log level:     DEBUG
+Deactivation:  2025-09-01T13:30:00Z (in 1h30m0s)
+
+CONDITION     STATUS  REASON        MESSAGE
+LogLevelsSet  True    LogLevelsSet  all log levels set
+
+KIND  NAME  ORIGINAL  APPLIED  STATE
+dogu  cas   WARN      DEBUG    Applied
+`, out)
+	})
+	t.Run("should print json", func(t *testing.T) {
+		// when
+		out, err := runCmd(t, fake.NewSimpleClientset(debugMode), "status", "-o", "json")
+
+		// then
+		require.NoError(t, err)
+		printed := &v1.DebugMode{}
+		require.NoError(t, json.Unmarshal([]byte(out), printed))
+		assert.Equal(t, "k8s.cloudogu.com/v1", printed.APIVersion)
+		assert.Equal(t, "DebugMode", printed.Kind)
+		assert.Equal(t, debugMode.Status, printed.Status)
+	})
+	t.Run("should print yaml", func(t *testing.T) {
+		// when
+		out, err := runCmd(t, fake.NewSimpleClientset(debugMode), "status", "-o", "yaml")
+
+		// then
+		require.NoError(t, err)
+		printed := &v1.DebugMode{}
+		require.NoError(t, yaml.Unmarshal([]byte(out), printed))
+		assert.Equal(t, "DebugMode", printed.Kind)
+		assert.Equal(t, debugMode.Spec.TargetLogLevel, printed.Spec.TargetLogLevel)
+	})
+	t.Run("should report inactive debug mode", func(t *testing.T) {
+		// when
+		out, err := runCmd(t, fake.NewSimpleClientset(), "status")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "debug mode is not active\n", out)
+	})
+	t.Run("should fail on invalid output format", func(t *testing.T) {
+		// when
+		_, err := runCmd(t, fake.NewSimpleClientset(debugMode), "status", "-o", "wide")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid output format \"wide\"")
+	})
+}
+
+func TestWatchCmd(t *testing.T) {
+	t.Run("should print changes until debug mode is deleted", func(t *testing.T) {
+		// given
+		clientSet := fake.NewSimpleClientset(newTestDebugMode(v1.DebugModeStatusSet))
+		clientSet.PrependWatchReactor("debugmodes", func(action clienttesting.Action) (bool, watch.Interface, error) {
+			waiting := newTestDebugMode(v1.DebugModeStatusWaitForRollback)
+			waiting.ResourceVersion = "2"
+			waiting.Spec.DeactivateTimestamp = metav1.NewTime(testNow)
+			deleted := waiting.DeepCopy()
+			deleted.ResourceVersion = "3"
+
+			watcher := watch.NewFakeWithChanSize(2, false)
+			watcher.Modify(waiting)
+			watcher.Delete(deleted)
+			return true, watcher, nil
+		})
+
+		// when
+		out, err := runCmd(t, clientSet, "watch")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, `TIME                    PHASE                   REMAINING
+2025-09-01T12:00:00Z    SetDebugMode            1h30m0s
+2025-09-01T12:00:00Z    WaitForRollback         -
+2025-09-01T12:00:00Z    <deleted>               -
+`, out)
+	})
+	t.Run("should stop on terminal phase", func(t *testing.T) {
+		// given
+		clientSet := fake.NewSimpleClientset(newTestDebugMode(v1.DebugModeStatusCompleted))
+		clientSet.PrependWatchReactor("debugmodes", func(action clienttesting.Action) (bool, watch.Interface, error) {
+			t.Fatal("should not watch a finished debug mode")
+			return false, nil, nil
+		})
+
+		// when
+		out, err := runCmd(t, clientSet, "watch", "-o", "json")
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, out, `"phase": "Completed"`)
+	})
+	t.Run("should continue with latest version if resource version is gone", func(t *testing.T) {
+		// given
+		clientSet := fake.NewSimpleClientset(newTestDebugMode(v1.DebugModeStatusSet))
+		clientSet.PrependWatchReactor("debugmodes", func(action clienttesting.Action) (bool, watch.Interface, error) {
+			// the debug mode completes while the watch is disconnected
+			completed := newTestDebugMode(v1.DebugModeStatusCompleted)
+			completed.ResourceVersion = "5"
+			require.NoError(t, clientSet.Tracker().Update(v1.GroupVersion.WithResource("debugmodes"), completed, "ecosystem"))
+
+			watcher := watch.NewFakeWithChanSize(1, false)
+			watcher.Error(&apierrors.NewGone("too old resource version").ErrStatus)
+			return true, watcher, nil
+		})
+
+		// when
+		out, err := runCmd(t, clientSet, "watch")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, `TIME                    PHASE                   REMAINING
+2025-09-01T12:00:00Z    SetDebugMode            1h30m0s
+2025-09-01T12:00:00Z    Completed               1h30m0s
+`, out)
+	})
+	t.Run("should fail if debug mode does not exist", func(t *testing.T) {
+		// when
+		_, err := runCmd(t, fake.NewSimpleClientset(), "watch")
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
@@ -0,0 +1,19 @@
+// Command kubectl-debugmode is a kubectl plugin to activate, extend, deactivate and inspect the debug mode of the
+// Cloudogu EcoSystem. Install the binary anywhere on the PATH and call it as "kubectl debugmode".
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := newRootCmd(newDefaultOptions()).ExecuteContext(ctx)
+	if err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+func validateOutputFormat(output string) error {
+	switch output {
+	case outputTable, outputJSON, outputYAML:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q, must be one of: %s, %s, %s", output, outputTable, outputJSON, outputYAML)
+	}
+}
+
+func printDebugMode(opts *options, debugMode *v1.DebugMode) error {
+	switch opts.output {
+	case outputJSON:
+		return printJSON(opts.out, debugMode)
+	case outputYAML:
+		return printYAML(opts.out, debugMode)
+	default:
+		return printTable(opts.out, debugMode, opts.now())
+	}
+}
+
+// withTypeMeta returns a copy of the debugMode with apiVersion and kind, which are not set on decoded objects,
+// so the printed resource can be applied again.
+func withTypeMeta(debugMode *v1.DebugMode) *v1.DebugMode {
+	result := debugMode.DeepCopy()
+	result.APIVersion = v1.GroupVersion.String()
+	result.Kind = "DebugMode"
+	return result
+}
+
+func printJSON(out io.Writer, debugMode *v1.DebugMode) error {
+	data, err := json.MarshalIndent(withTypeMeta(debugMode), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal debug mode to json: %w", err)
+	}
+
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+func printYAML(out io.Writer, debugMode *v1.DebugMode) error {
+	data, err := yaml.Marshal(withTypeMeta(debugMode))
+	if err != nil {
+		return fmt.Errorf("failed to marshal debug mode to yaml: %w", err)
+	}
+
+	_, err = out.Write(data)
+	return err
+}
+
+func printTable(out io.Writer, debugMode *v1.DebugMode, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(w, "Name:\t%s\n", debugMode.Name)
+	_, _ = fmt.Fprintf(w, "Phase:\t%s\n", phaseOrUnknown(debugMode.Status.Phase))
+	_, _ = fmt.Fprintf(w, "Log level:\t%s\n", debugMode.Spec.TargetLogLevel)
+	_, _ = fmt.Fprintf(w, "Deactivation:\t%s\n", formatDeactivation(debugMode, now))
+
+	if len(debugMode.Status.Conditions) > 0 {
+		_, _ = fmt.Fprintln(w, "\nCONDITION\tSTATUS\tREASON\tMESSAGE")
+		for _, condition := range debugMode.Status.Conditions {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason, condition.Message)
+		}
+	}
+
+	if len(debugMode.Status.Targets) > 0 {
+		_, _ = fmt.Fprintln(w, "\nKIND\tNAME\tORIGINAL\tAPPLIED\tSTATE")
+		for _, target := range debugMode.Status.Targets {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", target.Kind, target.Name, target.OriginalLogLevel, target.AppliedLogLevel, target.State)
+		}
+	}
+
+	return w.Flush()
+}
+
+func phaseOrUnknown(phase v1.StatusPhase) string {
+	if phase == "" {
+		return "<pending>"
+	}
+
+	return string(phase)
+}
+
+func formatDeactivation(debugMode *v1.DebugMode, now time.Time) string {
+	deactivationTime := debugMode.EffectiveDeactivationTime()
+	if deactivationTime.IsZero() {
+		return "<unknown>"
+	}
+
+	remaining := debugMode.RemainingDuration(now)
+	if remaining == 0 {
+		return fmt.Sprintf("%s (expired)", deactivationTime.UTC().Format(time.RFC3339))
+	}
+
+	return fmt.Sprintf("%s (in %s)", deactivationTime.UTC().Format(time.RFC3339), remaining.Round(time.Second))
+}
+
+// watchPrinter prints one entry per observed change of the debug mode.
+// Tables get a single header and one row per change, JSON and YAML print the complete resource.
+type watchPrinter struct {
+	opts          *options
+	headerPrinted bool
+}
+
+func newWatchPrinter(opts *options) *watchPrinter {
+	return &watchPrinter{opts: opts}
+}
+
+func (p *watchPrinter) print(debugMode *v1.DebugMode) error {
+	switch p.opts.output {
+	case outputJSON:
+		return printJSON(p.opts.out, debugMode)
+	case outputYAML:
+		_, err := fmt.Fprintln(p.opts.out, "---")
+		if err != nil {
+			return err
+		}
+		return printYAML(p.opts.out, debugMode)
+	default:
+		return p.printRow(phaseOrUnknown(debugMode.Status.Phase), formatRemaining(debugMode.RemainingDuration(p.opts.now())))
+	}
+}
+
+func (p *watchPrinter) printDeleted() error {
+	if p.opts.output != outputTable {
+		return nil
+	}
+
+	return p.printRow("<deleted>", "-")
+}
+
+func (p *watchPrinter) printRow(phase string, remaining string) error {
+	w := tabwriter.NewWriter(p.opts.out, 24, 0, 2, ' ', 0)
+	if !p.headerPrinted {
+		_, _ = fmt.Fprintln(w, "TIME\tPHASE\tREMAINING")
+		p.headerPrinted = true
+	}
+
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", p.opts.now().UTC().Format(time.RFC3339), phase, remaining)
+	return w.Flush()
+}
+
+func formatRemaining(remaining time.Duration) string {
+	if remaining == 0 {
+		return "-"
+	}
+
+	return remaining.Round(time.Second).String()
+}
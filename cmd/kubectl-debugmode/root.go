@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client"
+	clientv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+)
+
+// options contains the global flags and the dependencies shared by all subcommands.
+type options struct {
+	kubeconfig string
+	context    string
+	namespace  string
+	output     string
+
+	out       io.Writer
+	now       func() time.Time
+	newClient func(opts *options) (clientv1.DebugModeInterface, error)
+}
+
+func newDefaultOptions() *options {
+	return &options{out: os.Stdout, now: time.Now, newClient: newDebugModeClient}
+}
+
+func newRootCmd(opts *options) *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:          "kubectl-debugmode",
+		Short:        "Manage the debug mode of the Cloudogu EcoSystem",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateOutputFormat(opts.output)
+		},
+	}
+	rootCmd.SetOut(opts.out)
+
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&opts.kubeconfig, "kubeconfig", "", "path to the kubeconfig file")
+	flags.StringVar(&opts.context, "context", "", "name of the kubeconfig context to use")
+	flags.StringVarP(&opts.namespace, "namespace", "n", "", "namespace of the debug mode; defaults to the namespace of the kubeconfig context")
+	flags.StringVarP(&opts.output, "output", "o", outputTable, "output format, one of: table, json, yaml")
+
+	rootCmd.AddCommand(
+		newOnCmd(opts),
+		newExtendCmd(opts),
+		newOffCmd(opts),
+		newStatusCmd(opts),
+		newWatchCmd(opts),
+	)
+
+	return rootCmd
+}
+
+func newDebugModeClient(opts *options) (clientv1.DebugModeInterface, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = opts.kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{CurrentContext: opts.context})
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	namespace := opts.namespace
+	if namespace == "" {
+		namespace, _, err = clientConfig.Namespace()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get namespace from kubeconfig: %w", err)
+		}
+	}
+
+	clientSet, err := client.NewDebugModeClientSet(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create debugMode client: %w", err)
+	}
+
+	return clientSet.DebugModeV1().DebugMode(namespace), nil
+}
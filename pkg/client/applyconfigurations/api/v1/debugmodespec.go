@@ -0,0 +1,59 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DebugModeSpecApplyConfiguration represents a declarative configuration of the DebugModeSpec type for use
+// with apply.
+type DebugModeSpecApplyConfiguration struct {
+	DeactivateTimestamp *metav1.Time                       `json:"deactivateTimestamp,omitempty"`
+	Duration            *metav1.Duration                   `json:"duration,omitempty"`
+	TargetLogLevel      *string                            `json:"targetLogLevel,omitempty"`
+	Targets             []LogLevelTargetApplyConfiguration `json:"targets,omitempty"`
+}
+
+// DebugModeSpecApplyConfiguration constructs a declarative configuration of the DebugModeSpec type for use with
+// apply.
+func DebugModeSpec() *DebugModeSpecApplyConfiguration {
+	return &DebugModeSpecApplyConfiguration{}
+}
+
+// WithDeactivateTimestamp sets the DeactivateTimestamp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeactivateTimestamp field is set to the value of the last call.
+func (b *DebugModeSpecApplyConfiguration) WithDeactivateTimestamp(value metav1.Time) *DebugModeSpecApplyConfiguration {
+	b.DeactivateTimestamp = &value
+	return b
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *DebugModeSpecApplyConfiguration) WithDuration(value metav1.Duration) *DebugModeSpecApplyConfiguration {
+	b.Duration = &value
+	return b
+}
+
+// WithTargetLogLevel sets the TargetLogLevel field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TargetLogLevel field is set to the value of the last call.
+func (b *DebugModeSpecApplyConfiguration) WithTargetLogLevel(value string) *DebugModeSpecApplyConfiguration {
+	b.TargetLogLevel = &value
+	return b
+}
+
+// WithTargets adds the given value to the Targets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Targets field.
+func (b *DebugModeSpecApplyConfiguration) WithTargets(values ...*LogLevelTargetApplyConfiguration) *DebugModeSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTargets")
+		}
+		b.Targets = append(b.Targets, *values[i])
+	}
+	return b
+}
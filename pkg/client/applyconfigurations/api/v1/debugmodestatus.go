@@ -0,0 +1,89 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	apiv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// DebugModeStatusApplyConfiguration represents a declarative configuration of the DebugModeStatus type for use
+// with apply.
+type DebugModeStatusApplyConfiguration struct {
+	Phase            *apiv1.StatusPhase                   `json:"phase,omitempty"`
+	Errors           *string                              `json:"errors,omitempty"`
+	ErrorEntries     []ErrorEntryApplyConfiguration       `json:"errorEntries,omitempty"`
+	Conditions       []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	DeactivationTime *apismetav1.Time                     `json:"deactivationTime,omitempty"`
+	Targets          []TargetStatusApplyConfiguration     `json:"targets,omitempty"`
+}
+
+// DebugModeStatusApplyConfiguration constructs a declarative configuration of the DebugModeStatus type for use with
+// apply.
+func DebugModeStatus() *DebugModeStatusApplyConfiguration {
+	return &DebugModeStatusApplyConfiguration{}
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *DebugModeStatusApplyConfiguration) WithPhase(value apiv1.StatusPhase) *DebugModeStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithErrors sets the Errors field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Errors field is set to the value of the last call.
+func (b *DebugModeStatusApplyConfiguration) WithErrors(value string) *DebugModeStatusApplyConfiguration {
+	b.Errors = &value
+	return b
+}
+
+// WithErrorEntries adds the given value to the ErrorEntries field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ErrorEntries field.
+func (b *DebugModeStatusApplyConfiguration) WithErrorEntries(values ...*ErrorEntryApplyConfiguration) *DebugModeStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithErrorEntries")
+		}
+		b.ErrorEntries = append(b.ErrorEntries, *values[i])
+	}
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *DebugModeStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *DebugModeStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithDeactivationTime sets the DeactivationTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeactivationTime field is set to the value of the last call.
+func (b *DebugModeStatusApplyConfiguration) WithDeactivationTime(value apismetav1.Time) *DebugModeStatusApplyConfiguration {
+	b.DeactivationTime = &value
+	return b
+}
+
+// WithTargets adds the given value to the Targets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Targets field.
+func (b *DebugModeStatusApplyConfiguration) WithTargets(values ...*TargetStatusApplyConfiguration) *DebugModeStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTargets")
+		}
+		b.Targets = append(b.Targets, *values[i])
+	}
+	return b
+}
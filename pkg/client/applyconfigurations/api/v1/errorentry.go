@@ -0,0 +1,64 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	apiv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrorEntryApplyConfiguration represents a declarative configuration of the ErrorEntry type for use
+// with apply.
+type ErrorEntryApplyConfiguration struct {
+	Target    *string            `json:"target,omitempty"`
+	Phase     *apiv1.StatusPhase `json:"phase,omitempty"`
+	Timestamp *metav1.Time       `json:"timestamp,omitempty"`
+	Message   *string            `json:"message,omitempty"`
+	Retryable *bool              `json:"retryable,omitempty"`
+}
+
+// ErrorEntryApplyConfiguration constructs a declarative configuration of the ErrorEntry type for use with
+// apply.
+func ErrorEntry() *ErrorEntryApplyConfiguration {
+	return &ErrorEntryApplyConfiguration{}
+}
+
+// WithTarget sets the Target field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Target field is set to the value of the last call.
+func (b *ErrorEntryApplyConfiguration) WithTarget(value string) *ErrorEntryApplyConfiguration {
+	b.Target = &value
+	return b
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *ErrorEntryApplyConfiguration) WithPhase(value apiv1.StatusPhase) *ErrorEntryApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithTimestamp sets the Timestamp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timestamp field is set to the value of the last call.
+func (b *ErrorEntryApplyConfiguration) WithTimestamp(value metav1.Time) *ErrorEntryApplyConfiguration {
+	b.Timestamp = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ErrorEntryApplyConfiguration) WithMessage(value string) *ErrorEntryApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithRetryable sets the Retryable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Retryable field is set to the value of the last call.
+func (b *ErrorEntryApplyConfiguration) WithRetryable(value bool) *ErrorEntryApplyConfiguration {
+	b.Retryable = &value
+	return b
+}
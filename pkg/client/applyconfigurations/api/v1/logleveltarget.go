@@ -0,0 +1,45 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	apiv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+// LogLevelTargetApplyConfiguration represents a declarative configuration of the LogLevelTarget type for use
+// with apply.
+type LogLevelTargetApplyConfiguration struct {
+	Kind     *apiv1.TargetKind `json:"kind,omitempty"`
+	Name     *string           `json:"name,omitempty"`
+	LogLevel *string           `json:"logLevel,omitempty"`
+}
+
+// LogLevelTargetApplyConfiguration constructs a declarative configuration of the LogLevelTarget type for use with
+// apply.
+func LogLevelTarget() *LogLevelTargetApplyConfiguration {
+	return &LogLevelTargetApplyConfiguration{}
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *LogLevelTargetApplyConfiguration) WithKind(value apiv1.TargetKind) *LogLevelTargetApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *LogLevelTargetApplyConfiguration) WithName(value string) *LogLevelTargetApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithLogLevel sets the LogLevel field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LogLevel field is set to the value of the last call.
+func (b *LogLevelTargetApplyConfiguration) WithLogLevel(value string) *LogLevelTargetApplyConfiguration {
+	b.LogLevel = &value
+	return b
+}
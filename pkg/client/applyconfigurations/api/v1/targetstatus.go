@@ -0,0 +1,73 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	apiv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TargetStatusApplyConfiguration represents a declarative configuration of the TargetStatus type for use
+// with apply.
+type TargetStatusApplyConfiguration struct {
+	Kind               *apiv1.TargetKind  `json:"kind,omitempty"`
+	Name               *string            `json:"name,omitempty"`
+	OriginalLogLevel   *string            `json:"originalLogLevel,omitempty"`
+	AppliedLogLevel    *string            `json:"appliedLogLevel,omitempty"`
+	State              *apiv1.TargetState `json:"state,omitempty"`
+	LastTransitionTime *metav1.Time       `json:"lastTransitionTime,omitempty"`
+}
+
+// TargetStatusApplyConfiguration constructs a declarative configuration of the TargetStatus type for use with
+// apply.
+func TargetStatus() *TargetStatusApplyConfiguration {
+	return &TargetStatusApplyConfiguration{}
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *TargetStatusApplyConfiguration) WithKind(value apiv1.TargetKind) *TargetStatusApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *TargetStatusApplyConfiguration) WithName(value string) *TargetStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithOriginalLogLevel sets the OriginalLogLevel field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OriginalLogLevel field is set to the value of the last call.
+func (b *TargetStatusApplyConfiguration) WithOriginalLogLevel(value string) *TargetStatusApplyConfiguration {
+	b.OriginalLogLevel = &value
+	return b
+}
+
+// WithAppliedLogLevel sets the AppliedLogLevel field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AppliedLogLevel field is set to the value of the last call.
+func (b *TargetStatusApplyConfiguration) WithAppliedLogLevel(value string) *TargetStatusApplyConfiguration {
+	b.AppliedLogLevel = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *TargetStatusApplyConfiguration) WithState(value apiv1.TargetState) *TargetStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithLastTransitionTime sets the LastTransitionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastTransitionTime field is set to the value of the last call.
+func (b *TargetStatusApplyConfiguration) WithLastTransitionTime(value metav1.Time) *TargetStatusApplyConfiguration {
+	b.LastTransitionTime = &value
+	return b
+}
@@ -16,8 +16,9 @@ type clientSet struct {
 }
 
 // NewDebugModeClientSet creates a new instance of the debug mode client set.
-func NewDebugModeClientSet(config *rest.Config) (DebugModeEcosystemInterface, error) {
-	clientV1, err := v1.NewForConfig(config)
+// The options configure the helper functions of the debug mode clients.
+func NewDebugModeClientSet(config *rest.Config, opts ...v1.Option) (DebugModeEcosystemInterface, error) {
+	clientV1, err := v1.NewForConfig(config, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,111 @@
+// Package fake provides an in-memory implementation of the debug mode client set for tests.
+package fake
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/testing"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client"
+	clientv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+)
+
+// Clientset implements client.DebugModeEcosystemInterface. Its API operations are recorded as actions and handled by
+// reactors. By default, all actions are served from an in-memory object tracker.
+// Use AddReactor or PrependReactor to inject errors like conflicts for specific actions.
+type Clientset struct {
+	testing.Fake
+	tracker testing.ObjectTracker
+	opts    []clientv1.Option
+}
+
+var _ client.DebugModeEcosystemInterface = &Clientset{}
+
+// NewSimpleClientset returns a clientset that will respond with the provided objects.
+// It's backed by a very simple object tracker that processes creates, updates and deletions as-is,
+// without applying any validations and/or defaults. Server-side apply is supported including the
+// management of fields per field manager.
+func NewSimpleClientset(objects ...runtime.Object) *Clientset {
+	o := testing.NewFieldManagedObjectTracker(scheme, codecs.UniversalDecoder(), managedfields.NewDeducedTypeConverter())
+	for _, obj := range objects {
+		if err := o.Add(obj); err != nil {
+			panic(err)
+		}
+	}
+
+	cs := &Clientset{tracker: o}
+	cs.AddReactor("delete-collection", "*", deleteCollectionReaction(o))
+	cs.AddReactor("*", "*", testing.ObjectReaction(o))
+	cs.AddWatchReactor("*", func(action testing.Action) (handled bool, ret watch.Interface, err error) {
+		var opts metav1.ListOptions
+		if watchAction, ok := action.(testing.WatchActionImpl); ok {
+			opts = watchAction.ListOptions
+		}
+		gvr := action.GetResource()
+		ns := action.GetNamespace()
+		w, err := o.Watch(gvr, ns, opts)
+		if err != nil {
+			return false, nil, err
+		}
+		return true, w, nil
+	})
+
+	return cs
+}
+
+// Tracker gives access to the object tracker which holds the objects of this clientset.
+func (c *Clientset) Tracker() testing.ObjectTracker {
+	return c.tracker
+}
+
+// WithOptions configures the helper functions of the debugMode clients, e.g. clientv1.WithBackoff to retry injected
+// conflicts without waiting.
+func (c *Clientset) WithOptions(opts ...clientv1.Option) *Clientset {
+	c.opts = append(c.opts, opts...)
+	return c
+}
+
+// DebugModeV1 returns the fake debug mode v1 client.
+func (c *Clientset) DebugModeV1() clientv1.DebugModeV1Interface {
+	return &FakeDebugModeV1{Fake: &c.Fake, opts: c.opts}
+}
+
+// deleteCollectionReaction deletes all objects matching the label selector because testing.ObjectReaction does not
+// support collection deletes.
+func deleteCollectionReaction(tracker testing.ObjectTracker) testing.ReactionFunc {
+	return func(action testing.Action) (handled bool, ret runtime.Object, err error) {
+		deleteAction, ok := action.(testing.DeleteCollectionActionImpl)
+		if !ok {
+			return false, nil, nil
+		}
+
+		list, err := tracker.List(deleteAction.GetResource(), v1.GroupVersion.WithKind("DebugMode"), deleteAction.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+
+		selector := labels.Everything()
+		if deleteAction.ListOptions.LabelSelector != "" {
+			selector, err = labels.Parse(deleteAction.ListOptions.LabelSelector)
+			if err != nil {
+				return true, nil, err
+			}
+		}
+
+		for _, item := range list.(*v1.DebugModeList).Items {
+			if !selector.Matches(labels.Set(item.Labels)) {
+				continue
+			}
+			err = tracker.Delete(deleteAction.GetResource(), item.Namespace, item.Name)
+			if err != nil {
+				return true, nil, err
+			}
+		}
+
+		return true, nil, nil
+	}
+}
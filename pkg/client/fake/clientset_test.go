@@ -0,0 +1,79 @@
+package fake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clienttesting "k8s.io/client-go/testing"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	clientv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+)
+
+func TestNewSimpleClientset(t *testing.T) {
+	t.Run("should serve the given objects", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+
+		// when
+		sut := NewSimpleClientset(debugMode)
+
+		// then
+		result, err := sut.DebugModeV1().DebugMode("ecosystem").Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "debug-mode", result.Name)
+
+		obj, err := sut.Tracker().Get(debugModesResource, "ecosystem", "debug-mode")
+		require.NoError(t, err)
+		assert.Equal(t, debugMode, obj)
+	})
+	t.Run("should panic for objects of unknown types", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewSimpleClientset(&metav1.Status{})
+		})
+	})
+}
+
+func TestClientset_WithOptions(t *testing.T) {
+	t.Run("should configure the helper functions", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem", ResourceVersion: "1"}}
+		sut := NewSimpleClientset(debugMode).WithOptions(clientv1.WithBackoff(wait.Backoff{Steps: 2}))
+		sut.PrependReactor("update", "debugmodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewConflict(debugModesResource.GroupResource(), "debug-mode", assert.AnError)
+		})
+
+		// when
+		_, err := sut.DebugModeV1().DebugMode("ecosystem").AddFinalizer(testCtx, debugMode, "my-finalizer")
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsConflict(err))
+		assert.Len(t, sut.Actions(), 3, "update, get and update of the second attempt")
+	})
+}
+
+func TestClientset_DeleteCollection(t *testing.T) {
+	t.Run("should only delete debug modes matching the label selector", func(t *testing.T) {
+		// given
+		matching := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem", Labels: map[string]string{"app": "ces"}}}
+		other := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "other"}}
+		sut := NewSimpleClientset(matching, other)
+
+		// when
+		err := sut.DebugModeV1().DebugMode("").DeleteCollection(testCtx, metav1.DeleteOptions{}, metav1.ListOptions{LabelSelector: "app=ces"})
+
+		// then
+		require.NoError(t, err)
+		list, err := sut.DebugModeV1().DebugMode("").List(testCtx, metav1.ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, "other", list.Items[0].Namespace)
+	})
+}
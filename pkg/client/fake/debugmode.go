@@ -0,0 +1,116 @@
+package fake
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/testing"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	clientv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+)
+
+var debugModesResource = v1.GroupVersion.WithResource("debugmodes")
+
+var debugModesKind = v1.GroupVersion.WithKind("DebugMode")
+
+// FakeDebugModeV1 implements clientv1.DebugModeV1Interface.
+type FakeDebugModeV1 struct {
+	*testing.Fake
+	opts []clientv1.Option
+}
+
+// DebugMode returns a fake debugMode client for the given namespace.
+// The helper functions like UpdateStatusRollback run on top of the fake API operations,
+// so errors injected via reactors affect them as well.
+func (c *FakeDebugModeV1) DebugMode(namespace string) clientv1.DebugModeInterface {
+	return clientv1.NewDebugModeInterface(&FakeDebugModes{Fake: c, ns: namespace}, c.opts...)
+}
+
+// FakeDebugModes implements clientv1.DebugModeResourceInterface.
+type FakeDebugModes struct {
+	Fake *FakeDebugModeV1
+	ns   string
+}
+
+func (c *FakeDebugModes) Create(_ context.Context, debugMode *v1.DebugMode, opts metav1.CreateOptions) (result *v1.DebugMode, err error) {
+	emptyResult := &v1.DebugMode{}
+	obj, err := c.Fake.Invokes(testing.NewCreateActionWithOptions(debugModesResource, c.ns, debugMode, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.DebugMode), err
+}
+
+func (c *FakeDebugModes) Update(_ context.Context, debugMode *v1.DebugMode, opts metav1.UpdateOptions) (result *v1.DebugMode, err error) {
+	emptyResult := &v1.DebugMode{}
+	obj, err := c.Fake.Invokes(testing.NewUpdateActionWithOptions(debugModesResource, c.ns, debugMode, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.DebugMode), err
+}
+
+func (c *FakeDebugModes) UpdateStatus(_ context.Context, debugMode *v1.DebugMode, opts metav1.UpdateOptions) (result *v1.DebugMode, err error) {
+	emptyResult := &v1.DebugMode{}
+	obj, err := c.Fake.Invokes(testing.NewUpdateSubresourceActionWithOptions(debugModesResource, "status", c.ns, debugMode, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.DebugMode), err
+}
+
+func (c *FakeDebugModes) Delete(_ context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.Invokes(testing.NewDeleteActionWithOptions(debugModesResource, c.ns, name, opts), &v1.DebugMode{})
+	return err
+}
+
+func (c *FakeDebugModes) DeleteCollection(_ context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	_, err := c.Fake.Invokes(testing.NewDeleteCollectionActionWithOptions(debugModesResource, c.ns, opts, listOpts), &v1.DebugModeList{})
+	return err
+}
+
+func (c *FakeDebugModes) Get(_ context.Context, name string, opts metav1.GetOptions) (result *v1.DebugMode, err error) {
+	emptyResult := &v1.DebugMode{}
+	obj, err := c.Fake.Invokes(testing.NewGetActionWithOptions(debugModesResource, c.ns, name, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.DebugMode), err
+}
+
+func (c *FakeDebugModes) List(_ context.Context, opts metav1.ListOptions) (result *v1.DebugModeList, err error) {
+	emptyResult := &v1.DebugModeList{}
+	obj, err := c.Fake.Invokes(testing.NewListActionWithOptions(debugModesResource, debugModesKind, c.ns, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.DebugModeList{ListMeta: obj.(*v1.DebugModeList).ListMeta}
+	for _, item := range obj.(*v1.DebugModeList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+func (c *FakeDebugModes) Watch(_ context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.InvokesWatch(testing.NewWatchActionWithOptions(debugModesResource, c.ns, opts))
+}
+
+func (c *FakeDebugModes) Patch(_ context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.DebugMode, err error) {
+	emptyResult := &v1.DebugMode{}
+	obj, err := c.Fake.Invokes(testing.NewPatchSubresourceActionWithOptions(debugModesResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.DebugMode), err
+}
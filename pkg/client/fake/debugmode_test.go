@@ -0,0 +1,281 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	clienttesting "k8s.io/client-go/testing"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	applyv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/applyconfigurations/api/v1"
+	clientv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+)
+
+var testCtx = context.Background()
+
+var testBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+func TestFakeDebugModes_CRUD(t *testing.T) {
+	t.Run("should create, update and delete a debug mode", func(t *testing.T) {
+		// given
+		sut := NewSimpleClientset().DebugModeV1().DebugMode("ecosystem")
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"},
+			Spec:       v1.DebugModeSpec{TargetLogLevel: "DEBUG"},
+		}
+
+		// when
+		created, err := sut.Create(testCtx, debugMode, metav1.CreateOptions{})
+		require.NoError(t, err)
+		created.Spec.TargetLogLevel = "INFO"
+		updated, err := sut.Update(testCtx, created, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		err = sut.Delete(testCtx, "debug-mode", metav1.DeleteOptions{})
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, "INFO", updated.Spec.TargetLogLevel)
+		_, err = sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+	t.Run("should fail to create an existing debug mode", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewSimpleClientset(debugMode).DebugModeV1().DebugMode("ecosystem")
+
+		// when
+		_, err := sut.Create(testCtx, debugMode, metav1.CreateOptions{})
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsAlreadyExists(err))
+	})
+}
+
+func TestFakeDebugModes_Patch(t *testing.T) {
+	t.Run("should apply merge patch to status", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewSimpleClientset(debugMode).DebugModeV1().DebugMode("ecosystem")
+
+		// when
+		result, err := sut.Patch(testCtx, "debug-mode", types.MergePatchType, []byte(`{"status":{"phase":"Rollback"}}`), metav1.PatchOptions{}, "status")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusRollback, result.Status.Phase)
+	})
+}
+
+func TestFakeDebugModes_Watch(t *testing.T) {
+	t.Run("should receive events for changes", func(t *testing.T) {
+		// given
+		clientSet := NewSimpleClientset()
+		sut := clientSet.DebugModeV1().DebugMode("ecosystem")
+		watcher, err := sut.Watch(testCtx, metav1.ListOptions{})
+		require.NoError(t, err)
+		defer watcher.Stop()
+
+		// when
+		_, err = sut.Create(testCtx, &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		// then
+		select {
+		case event := <-watcher.ResultChan():
+			assert.Equal(t, watch.Added, event.Type)
+			assert.Equal(t, "debug-mode", event.Object.(*v1.DebugMode).Name)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for watch event")
+		}
+	})
+}
+
+func TestFakeDebugModes_UpdateStatusRollback(t *testing.T) {
+	t.Run("should update phase", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"},
+			Status:     v1.DebugModeStatus{Phase: v1.DebugModeStatusWaitForRollback},
+		}
+		clientSet := NewSimpleClientset(debugMode)
+		sut := clientSet.DebugModeV1().DebugMode("ecosystem")
+
+		// when
+		result, err := sut.UpdateStatusRollback(testCtx, debugMode)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusRollback, result.Status.Phase)
+		stored, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusRollback, stored.Status.Phase)
+	})
+	t.Run("should retry on injected conflict", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"},
+			Status:     v1.DebugModeStatus{Phase: v1.DebugModeStatusWaitForRollback},
+		}
+		clientSet := NewSimpleClientset(debugMode).WithOptions(clientv1.WithBackoff(testBackoff))
+		conflicts := 0
+		clientSet.PrependReactor("patch", "debugmodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "status" || conflicts > 0 {
+				return false, nil, nil
+			}
+			conflicts++
+			return true, nil, apierrors.NewConflict(debugModesResource.GroupResource(), "debug-mode", errors.New("stale"))
+		})
+		sut := clientSet.DebugModeV1().DebugMode("ecosystem")
+
+		// when
+		result, err := sut.UpdateStatusRollback(testCtx, debugMode)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, conflicts)
+		assert.Equal(t, v1.DebugModeStatusRollback, result.Status.Phase)
+	})
+	t.Run("should return injected error", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"},
+			Status:     v1.DebugModeStatus{Phase: v1.DebugModeStatusWaitForRollback},
+		}
+		clientSet := NewSimpleClientset(debugMode)
+		clientSet.PrependReactor("patch", "debugmodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, assert.AnError
+		})
+		sut := clientSet.DebugModeV1().DebugMode("ecosystem")
+
+		// when
+		_, err := sut.UpdateStatusRollback(testCtx, debugMode)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should refuse illegal transition", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"},
+			Status:     v1.DebugModeStatus{Phase: v1.DebugModeStatusCompleted},
+		}
+		sut := NewSimpleClientset(debugMode).DebugModeV1().DebugMode("ecosystem")
+
+		// when
+		_, err := sut.UpdateStatusRollback(testCtx, debugMode)
+
+		// then
+		require.Error(t, err)
+		var transitionErr *v1.IllegalPhaseTransitionError
+		require.ErrorAs(t, err, &transitionErr)
+		assert.Equal(t, v1.DebugModeStatusCompleted, transitionErr.From)
+		assert.Equal(t, v1.DebugModeStatusRollback, transitionErr.To)
+		stored, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusCompleted, stored.Status.Phase)
+	})
+}
+
+func TestFakeDebugModes_UpdateStatusDebugModeSet(t *testing.T) {
+	t.Run("should resolve deactivation time from duration", func(t *testing.T) {
+		// given
+		created := metav1.NewTime(time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC))
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem", CreationTimestamp: created},
+			Spec:       v1.DebugModeSpec{Duration: &metav1.Duration{Duration: time.Hour}},
+		}
+		sut := NewSimpleClientset(debugMode).DebugModeV1().DebugMode("ecosystem")
+
+		// when
+		result, err := sut.UpdateStatusDebugModeSet(testCtx, debugMode)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusSet, result.Status.Phase)
+		require.NotNil(t, result.Status.DeactivationTime)
+		assert.True(t, created.Add(time.Hour).Equal(result.Status.DeactivationTime.Time))
+	})
+	t.Run("should not set deactivation time if it cannot be resolved", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewSimpleClientset(debugMode).DebugModeV1().DebugMode("ecosystem")
+
+		// when
+		result, err := sut.UpdateStatusDebugModeSet(testCtx, debugMode)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, result.Status.DeactivationTime)
+	})
+}
+
+func TestFakeDebugModes_Helpers(t *testing.T) {
+	t.Run("should add finalizer and condition", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem", ResourceVersion: "1"}}
+		clientSet := NewSimpleClientset(debugMode)
+		sut := clientSet.DebugModeV1().DebugMode("ecosystem")
+
+		// when
+		withFinalizer, err := sut.AddFinalizer(testCtx, debugMode, "my-finalizer")
+		require.NoError(t, err)
+		result, err := sut.AddOrUpdateLogLevelsSet(testCtx, withFinalizer, true, "all set", "LevelsApplied")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"my-finalizer"}, result.Finalizers)
+		assert.True(t, meta.IsStatusConditionTrue(result.Status.Conditions, v1.ConditionLogLevelSet))
+		assert.Len(t, clientSet.Actions(), 2)
+	})
+}
+
+func TestFakeDebugModes_Apply(t *testing.T) {
+	t.Run("should keep fields of different field managers", func(t *testing.T) {
+		// given
+		sut := NewSimpleClientset().DebugModeV1().DebugMode("ecosystem")
+		uiConfig := applyv1.DebugMode("debug-mode", "ecosystem").
+			WithSpec(applyv1.DebugModeSpec().WithTargetLogLevel("DEBUG"))
+		operatorConfig := applyv1.DebugMode("debug-mode", "ecosystem").
+			WithSpec(applyv1.DebugModeSpec().WithDeactivateTimestamp(metav1.NewTime(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))))
+
+		// when
+		_, err := sut.Apply(testCtx, uiConfig, metav1.ApplyOptions{FieldManager: "ui"})
+		require.NoError(t, err)
+		result, err := sut.Apply(testCtx, operatorConfig, metav1.ApplyOptions{FieldManager: "operator"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "DEBUG", result.Spec.TargetLogLevel)
+		assert.Equal(t, 2030, result.Spec.DeactivateTimestamp.Year())
+		assert.Len(t, result.ManagedFields, 2)
+	})
+	t.Run("should return conflict if another field manager owns the field", func(t *testing.T) {
+		// given
+		sut := NewSimpleClientset().DebugModeV1().DebugMode("ecosystem")
+		_, err := sut.Apply(testCtx, applyv1.DebugMode("debug-mode", "ecosystem").
+			WithSpec(applyv1.DebugModeSpec().WithTargetLogLevel("DEBUG")), metav1.ApplyOptions{FieldManager: "ui"})
+		require.NoError(t, err)
+
+		// when
+		_, err = sut.Apply(testCtx, applyv1.DebugMode("debug-mode", "ecosystem").
+			WithSpec(applyv1.DebugModeSpec().WithTargetLogLevel("INFO")), metav1.ApplyOptions{FieldManager: "cli"})
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsConflict(err))
+	})
+}
@@ -0,0 +1,121 @@
+package informers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client"
+)
+
+func newTestClientSet(t *testing.T, debugModes ...v1.DebugMode) client.DebugModeEcosystemInterface {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, http.MethodGet, request.Method)
+		assert.Equal(t, "/apis/k8s.cloudogu.com/v1/namespaces/ecosystem/debugmodes", request.URL.Path)
+
+		if request.URL.Query().Get("watch") == "true" {
+			// keep the watch open without events until the informer stops
+			writer.Header().Add("content-type", "application/json")
+			writer.WriteHeader(http.StatusOK)
+			writer.(http.Flusher).Flush()
+			<-request.Context().Done()
+			return
+		}
+
+		list := &v1.DebugModeList{ListMeta: metav1.ListMeta{ResourceVersion: "1"}, Items: debugModes}
+		listBytes, err := json.Marshal(list)
+		require.NoError(t, err)
+
+		writer.Header().Add("content-type", "application/json")
+		_, err = writer.Write(listBytes)
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	clientSet, err := client.NewDebugModeClientSet(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	return clientSet
+}
+
+func TestNewSharedInformerFactoryWithOptions(t *testing.T) {
+	t.Run("should sync debug modes into the lister", func(t *testing.T) {
+		// given
+		debugMode := v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem", ResourceVersion: "1"}}
+		sut := NewSharedInformerFactoryWithOptions(newTestClientSet(t, debugMode), time.Minute, WithNamespace("ecosystem"))
+		debugModeInformer := sut.DebugModeV1().DebugModes()
+		// the informer must be requested before the factory is started
+		_ = debugModeInformer.Informer()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer func() {
+			cancel()
+			sut.Shutdown()
+		}()
+
+		// when
+		sut.Start(ctx.Done())
+		synced := sut.WaitForCacheSync(ctx.Done())
+
+		// then
+		require.Len(t, synced, 1)
+		for _, ok := range synced {
+			require.True(t, ok)
+		}
+
+		result, err := debugModeInformer.Lister().DebugModes("ecosystem").Get("debug-mode")
+		require.NoError(t, err)
+		assert.Equal(t, "debug-mode", result.Name)
+	})
+	t.Run("should apply tweak list options", func(t *testing.T) {
+		// given
+		var tweaked atomic.Bool
+		sut := NewSharedInformerFactoryWithOptions(newTestClientSet(t), time.Minute,
+			WithNamespace("ecosystem"),
+			WithTweakListOptions(func(options *metav1.ListOptions) {
+				tweaked.Store(true)
+			}),
+		)
+		_ = sut.DebugModeV1().DebugModes().Informer()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer func() {
+			cancel()
+			sut.Shutdown()
+		}()
+
+		// when
+		sut.Start(ctx.Done())
+		sut.WaitForCacheSync(ctx.Done())
+
+		// then
+		assert.True(t, tweaked.Load())
+	})
+}
+
+func Test_sharedInformerFactory_InformerFor(t *testing.T) {
+	t.Run("should share informers of the same type", func(t *testing.T) {
+		// given
+		sut := NewSharedInformerFactory(newTestClientSet(t), time.Minute)
+
+		// when
+		first := sut.DebugModeV1().DebugModes().Informer()
+		second := sut.DebugModeV1().DebugModes().Informer()
+
+		// then
+		assert.Same(t, first, second)
+	})
+}
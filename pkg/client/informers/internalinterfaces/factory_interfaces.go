@@ -0,0 +1,24 @@
+// Package internalinterfaces contains the interfaces shared between the informer factory and the typed informers.
+package internalinterfaces
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client"
+)
+
+// NewInformerFunc takes client.DebugModeEcosystemInterface and time.Duration to return a SharedIndexInformer.
+type NewInformerFunc func(client.DebugModeEcosystemInterface, time.Duration) cache.SharedIndexInformer
+
+// SharedInformerFactory a small interface to allow for adding an informer without an import cycle.
+type SharedInformerFactory interface {
+	Start(stopCh <-chan struct{})
+	InformerFor(obj runtime.Object, newFunc NewInformerFunc) cache.SharedIndexInformer
+}
+
+// TweakListOptionsFunc is a function that transforms a metav1.ListOptions.
+type TweakListOptionsFunc func(*metav1.ListOptions)
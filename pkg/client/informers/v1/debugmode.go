@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client"
+	"github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/informers/internalinterfaces"
+	listersv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/listers/v1"
+)
+
+// DebugModeInformer provides access to a shared informer and lister for DebugModes.
+type DebugModeInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() listersv1.DebugModeLister
+}
+
+type debugModeInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewDebugModeInformer constructs a new informer for DebugMode type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewDebugModeInformer(client client.DebugModeEcosystemInterface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredDebugModeInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredDebugModeInformer constructs a new informer for DebugMode type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredDebugModeInformer(client client.DebugModeEcosystemInterface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListWithContextFunc: func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.DebugModeV1().DebugMode(namespace).List(ctx, options)
+			},
+			WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.DebugModeV1().DebugMode(namespace).Watch(ctx, options)
+			},
+		},
+		&v1.DebugMode{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *debugModeInformer) defaultInformer(client client.DebugModeEcosystemInterface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredDebugModeInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+// Informer returns the shared informer for DebugModes.
+func (f *debugModeInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&v1.DebugMode{}, f.defaultInformer)
+}
+
+// Lister returns a lister which reads DebugModes from the cache of the shared informer.
+func (f *debugModeInformer) Lister() listersv1.DebugModeLister {
+	return listersv1.NewDebugModeLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,28 @@
+// Package v1 contains shared informers for the debug mode v1 resources.
+package v1
+
+import (
+	"github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/informers/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// DebugModes returns a DebugModeInformer.
+	DebugModes() DebugModeInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// DebugModes returns a DebugModeInformer.
+func (v *version) DebugModes() DebugModeInformer {
+	return &debugModeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
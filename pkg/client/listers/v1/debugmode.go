@@ -0,0 +1,53 @@
+// Package v1 contains listers for the debug mode v1 resources which read from a local informer cache.
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+// DebugModeLister helps list DebugModes.
+// All objects returned here must be treated as read-only.
+type DebugModeLister interface {
+	// List lists all DebugModes in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.DebugMode, err error)
+	// DebugModes returns an object that can list and get DebugModes.
+	DebugModes(namespace string) DebugModeNamespaceLister
+}
+
+// debugModeLister implements the DebugModeLister interface.
+type debugModeLister struct {
+	listers.ResourceIndexer[*v1.DebugMode]
+}
+
+// NewDebugModeLister returns a new DebugModeLister.
+func NewDebugModeLister(indexer cache.Indexer) DebugModeLister {
+	resource := schema.GroupResource{Group: v1.GroupVersion.Group, Resource: "debugmode"}
+	return &debugModeLister{listers.New[*v1.DebugMode](indexer, resource)}
+}
+
+// DebugModes returns an object that can list and get DebugModes.
+func (s *debugModeLister) DebugModes(namespace string) DebugModeNamespaceLister {
+	return debugModeNamespaceLister{listers.NewNamespaced[*v1.DebugMode](s.ResourceIndexer, namespace)}
+}
+
+// DebugModeNamespaceLister helps list and get DebugModes.
+// All objects returned here must be treated as read-only.
+type DebugModeNamespaceLister interface {
+	// List lists all DebugModes in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.DebugMode, err error)
+	// Get retrieves the DebugMode from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.DebugMode, error)
+}
+
+// debugModeNamespaceLister implements the DebugModeNamespaceLister interface.
+type debugModeNamespaceLister struct {
+	listers.ResourceIndexer[*v1.DebugMode]
+}
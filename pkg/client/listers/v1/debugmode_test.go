@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+func newTestIndexer(t *testing.T, debugModes ...*v1.DebugMode) cache.Indexer {
+	t.Helper()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, debugMode := range debugModes {
+		require.NoError(t, indexer.Add(debugMode))
+	}
+
+	return indexer
+}
+
+func Test_debugModeLister_List(t *testing.T) {
+	t.Run("should list debug modes of all namespaces matching the selector", func(t *testing.T) {
+		// given
+		first := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem", Labels: map[string]string{"app": "ces"}}}
+		second := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "other", Labels: map[string]string{"app": "ces"}}}
+		third := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "foreign"}}
+		sut := NewDebugModeLister(newTestIndexer(t, first, second, third))
+
+		// when
+		result, err := sut.List(labels.SelectorFromSet(labels.Set{"app": "ces"}))
+
+		// then
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []*v1.DebugMode{first, second}, result)
+	})
+}
+
+func Test_debugModeNamespaceLister(t *testing.T) {
+	first := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+	second := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "other"}}
+
+	t.Run("should list only debug modes of the namespace", func(t *testing.T) {
+		// given
+		sut := NewDebugModeLister(newTestIndexer(t, first, second))
+
+		// when
+		result, err := sut.DebugModes("ecosystem").List(labels.Everything())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []*v1.DebugMode{first}, result)
+	})
+	t.Run("should get debug mode by name", func(t *testing.T) {
+		// given
+		sut := NewDebugModeLister(newTestIndexer(t, first, second))
+
+		// when
+		result, err := sut.DebugModes("other").Get("debug-mode")
+
+		// then
+		require.NoError(t, err)
+		assert.Same(t, second, result)
+	})
+	t.Run("should return not found error", func(t *testing.T) {
+		// given
+		sut := NewDebugModeLister(newTestIndexer(t, first))
+
+		// when
+		_, err := sut.DebugModes("other").Get("debug-mode")
+
+		// then
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFound(err))
+	})
+}
@@ -12,10 +12,12 @@ import (
 // client wraps the rest.Interface to use as a restClient for the component client.
 type client struct {
 	restClient rest.Interface
+	opts       []Option
 }
 
 // NewForConfig creates a new client for a given rest.Config.
-func NewForConfig(c *rest.Config) (DebugModeV1Interface, error) {
+// The options configure the helper functions of the debugMode clients.
+func NewForConfig(c *rest.Config, opts ...Option) (DebugModeV1Interface, error) {
 	config := *c
 	gv := schema.GroupVersion{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version}
 	config.ContentConfig.GroupVersion = &gv
@@ -36,13 +38,13 @@ func NewForConfig(c *rest.Config) (DebugModeV1Interface, error) {
 		return nil, err
 	}
 
-	return &client{restClient: restClient}, nil
+	return &client{restClient: restClient, opts: opts}, nil
 }
 
 // DebugMode takes a namespace and returns a debugMode client.
 func (c *client) DebugMode(namespace string) DebugModeInterface {
-	return &debugModeClient{
+	return NewDebugModeInterface(&debugModeClient{
 		client: c.restClient,
 		ns:     namespace,
-	}
+	}, c.opts...)
 }
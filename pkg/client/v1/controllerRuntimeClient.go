@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+// NewForControllerRuntimeClient creates a debugMode client for the given namespace on top of a controller-runtime
+// client, e.g. the cached client of a manager. Reads are then served from the manager cache and the helper functions
+// resolve conflicts caused by a stale cache by retrying.
+// The scheme of the client must contain the types of this library, see v1.AddToScheme.
+// Watch and the WaitFor* helpers require a client that implements client.WithWatch; the client of a manager does not.
+func NewForControllerRuntimeClient(c ctrlclient.Client, namespace string, opts ...Option) DebugModeInterface {
+	return NewDebugModeInterface(&controllerRuntimeDebugModeClient{client: c, ns: namespace}, opts...)
+}
+
+// controllerRuntimeDebugModeClient implements the plain API operations for debugModes using a controller-runtime client.
+type controllerRuntimeDebugModeClient struct {
+	client ctrlclient.Client
+	ns     string
+}
+
+func (client *controllerRuntimeDebugModeClient) Create(ctx context.Context, debugMode *v1.DebugMode, opts metav1.CreateOptions) (*v1.DebugMode, error) {
+	result := client.inNamespace(debugMode)
+	err := client.client.Create(ctx, result, &ctrlclient.CreateOptions{Raw: &opts})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (client *controllerRuntimeDebugModeClient) Update(ctx context.Context, debugMode *v1.DebugMode, opts metav1.UpdateOptions) (*v1.DebugMode, error) {
+	result := client.inNamespace(debugMode)
+	err := client.client.Update(ctx, result, &ctrlclient.UpdateOptions{Raw: &opts})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (client *controllerRuntimeDebugModeClient) UpdateStatus(ctx context.Context, debugMode *v1.DebugMode, opts metav1.UpdateOptions) (*v1.DebugMode, error) {
+	result := client.inNamespace(debugMode)
+	err := client.client.Status().Update(ctx, result, &ctrlclient.SubResourceUpdateOptions{UpdateOptions: ctrlclient.UpdateOptions{Raw: &opts}})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (client *controllerRuntimeDebugModeClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: client.ns}}
+	return client.client.Delete(ctx, debugMode, &ctrlclient.DeleteOptions{Raw: &opts})
+}
+
+func (client *controllerRuntimeDebugModeClient) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	listOptions, err := client.listOptions(listOpts)
+	if err != nil {
+		return err
+	}
+
+	return client.client.DeleteAllOf(ctx, &v1.DebugMode{}, &ctrlclient.DeleteAllOfOptions{
+		ListOptions:   *listOptions,
+		DeleteOptions: ctrlclient.DeleteOptions{Raw: &opts},
+	})
+}
+
+func (client *controllerRuntimeDebugModeClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.DebugMode, error) {
+	result := &v1.DebugMode{}
+	err := client.client.Get(ctx, types.NamespacedName{Namespace: client.ns, Name: name}, result, &ctrlclient.GetOptions{Raw: &opts})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (client *controllerRuntimeDebugModeClient) List(ctx context.Context, opts metav1.ListOptions) (*v1.DebugModeList, error) {
+	listOptions, err := client.listOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &v1.DebugModeList{}
+	err = client.client.List(ctx, result, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (client *controllerRuntimeDebugModeClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	watchClient, ok := client.client.(ctrlclient.WithWatch)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(v1.GroupVersion.WithResource("debugmodes").GroupResource(), "watch")
+	}
+
+	listOptions, err := client.listOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return watchClient.Watch(ctx, &v1.DebugModeList{}, listOptions)
+}
+
+func (client *controllerRuntimeDebugModeClient) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*v1.DebugMode, error) {
+	result := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: client.ns}}
+	patch := ctrlclient.RawPatch(pt, data)
+
+	var err error
+	switch len(subresources) {
+	case 0:
+		err = client.client.Patch(ctx, result, patch, &ctrlclient.PatchOptions{Raw: &opts})
+	case 1:
+		err = client.client.SubResource(subresources[0]).Patch(ctx, result, patch, &ctrlclient.SubResourcePatchOptions{PatchOptions: ctrlclient.PatchOptions{Raw: &opts}})
+	default:
+		return nil, fmt.Errorf("failed to patch debugMode %s: nested subresources %v are not supported", name, subresources)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// inNamespace returns a copy of the debugMode in the namespace of the client, as the controller-runtime client
+// writes the given object and takes the namespace from it.
+func (client *controllerRuntimeDebugModeClient) inNamespace(debugMode *v1.DebugMode) *v1.DebugMode {
+	result := debugMode.DeepCopy()
+	result.Namespace = client.ns
+	return result
+}
+
+// listOptions converts the options to controller-runtime list options. The selectors are parsed, as the cache of a
+// manager only evaluates parsed selectors.
+func (client *controllerRuntimeDebugModeClient) listOptions(opts metav1.ListOptions) (*ctrlclient.ListOptions, error) {
+	listOptions := &ctrlclient.ListOptions{Namespace: client.ns, Limit: opts.Limit, Continue: opts.Continue, Raw: &opts}
+
+	if opts.LabelSelector != "" {
+		labelSelector, err := labels.Parse(opts.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse label selector %q: %w", opts.LabelSelector, err)
+		}
+		listOptions.LabelSelector = labelSelector
+	}
+
+	if opts.FieldSelector != "" {
+		fieldSelector, err := fields.ParseSelector(opts.FieldSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse field selector %q: %w", opts.FieldSelector, err)
+		}
+		listOptions.FieldSelector = fieldSelector
+	}
+
+	return listOptions, nil
+}
@@ -0,0 +1,208 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+func newControllerRuntimeClient(t *testing.T, funcs interceptor.Funcs, objects ...ctrlclient.Object) ctrlclient.WithWatch {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+
+	return ctrlfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&v1.DebugMode{}).
+		WithObjects(objects...).
+		WithInterceptorFuncs(funcs).
+		Build()
+}
+
+func Test_controllerRuntimeDebugModeClient_CRUD(t *testing.T) {
+	t.Run("should create, get, update and delete debugMode in namespace of the client", func(t *testing.T) {
+		// given
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}), "ecosystem")
+
+		// when
+		created, err := sut.Create(testCtx, &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode"}, Spec: v1.DebugModeSpec{TargetLogLevel: v1.LogLevelDebug}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+		created.Spec.TargetLogLevel = v1.LogLevelInfo
+		updated, err := sut.Update(testCtx, created, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		fetched, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+		err = sut.Delete(testCtx, "debug-mode", metav1.DeleteOptions{})
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, "ecosystem", created.Namespace)
+		assert.NotEmpty(t, created.ResourceVersion)
+		assert.Equal(t, v1.LogLevelInfo, updated.Spec.TargetLogLevel)
+		assert.Equal(t, v1.LogLevelInfo, fetched.Spec.TargetLogLevel)
+		_, err = sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+	t.Run("should list with label selector", func(t *testing.T) {
+		// given
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{},
+			&v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "ecosystem", Labels: map[string]string{"app": "ces"}}},
+			&v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "ecosystem"}},
+			&v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "third", Namespace: "other", Labels: map[string]string{"app": "ces"}}},
+		), "ecosystem")
+
+		// when
+		list, err := sut.List(testCtx, metav1.ListOptions{LabelSelector: "app=ces"})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, "first", list.Items[0].Name)
+	})
+	t.Run("should fail on invalid label selector", func(t *testing.T) {
+		// given
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}), "ecosystem")
+
+		// when
+		_, err := sut.List(testCtx, metav1.ListOptions{LabelSelector: "app in ("})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse label selector \"app in (\"")
+	})
+	t.Run("should delete collection", func(t *testing.T) {
+		// given
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{},
+			&v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "ecosystem"}},
+			&v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "other"}},
+		), "ecosystem")
+
+		// when
+		err := sut.DeleteCollection(testCtx, metav1.DeleteOptions{}, metav1.ListOptions{})
+
+		// then
+		require.NoError(t, err)
+		_, err = sut.Get(testCtx, "first", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func Test_controllerRuntimeDebugModeClient_Helpers(t *testing.T) {
+	t.Run("should update phase and conditions via status subresource", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode), "ecosystem")
+
+		// when
+		withFinalizer, err := sut.AddFinalizer(testCtx, debugMode, "my-finalizer")
+		require.NoError(t, err)
+		set, err := sut.UpdateStatusDebugModeSet(testCtx, withFinalizer)
+		require.NoError(t, err)
+		result, err := sut.AddOrUpdateLogLevelsSet(testCtx, set, true, "all set", "LevelsApplied")
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, []string{"my-finalizer"}, result.Finalizers)
+		assert.Equal(t, v1.DebugModeStatusSet, result.Status.Phase)
+		assert.True(t, meta.IsStatusConditionTrue(result.Status.Conditions, v1.ConditionLogLevelSet))
+		stored, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusSet, stored.Status.Phase)
+		assert.Len(t, stored.Status.Conditions, 1)
+	})
+	t.Run("should retry status patch on conflict", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusSet}}
+		conflicts := 0
+		c := newControllerRuntimeClient(t, interceptor.Funcs{
+			SubResourcePatch: func(ctx context.Context, client ctrlclient.Client, subResourceName string, obj ctrlclient.Object, patch ctrlclient.Patch, opts ...ctrlclient.SubResourcePatchOption) error {
+				if conflicts == 0 {
+					conflicts++
+					return apierrors.NewConflict(v1.GroupVersion.WithResource("debugmodes").GroupResource(), obj.GetName(), assert.AnError)
+				}
+				return client.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		}, debugMode)
+		sut := NewForControllerRuntimeClient(c, "ecosystem", WithBackoff(testBackoff))
+
+		// when
+		result, err := sut.UpdateStatusWaitForRollback(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, conflicts)
+		assert.Equal(t, v1.DebugModeStatusWaitForRollback, result.Status.Phase)
+	})
+}
+
+func Test_controllerRuntimeDebugModeClient_Patch(t *testing.T) {
+	t.Run("should patch debugMode", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode), "ecosystem")
+
+		// when
+		result, err := sut.Patch(testCtx, "debug-mode", types.MergePatchType, []byte(`{"spec":{"targetLogLevel":"WARN"}}`), metav1.PatchOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.LogLevelWarn, result.Spec.TargetLogLevel)
+	})
+	t.Run("should fail on nested subresources", func(t *testing.T) {
+		// given
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}), "ecosystem")
+
+		// when
+		_, err := sut.Patch(testCtx, "debug-mode", types.MergePatchType, []byte(`{}`), metav1.PatchOptions{}, "status", "scale")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "nested subresources [status scale] are not supported")
+	})
+}
+
+func Test_controllerRuntimeDebugModeClient_Watch(t *testing.T) {
+	t.Run("should watch with client that supports watches", func(t *testing.T) {
+		// given
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}), "ecosystem")
+
+		// when
+		watcher, err := sut.Watch(testCtx, metav1.ListOptions{})
+		require.NoError(t, err)
+		defer watcher.Stop()
+		_, err = sut.Create(testCtx, &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode"}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		// then
+		event := <-watcher.ResultChan()
+		assert.Equal(t, watch.Added, event.Type)
+		assert.Equal(t, "debug-mode", event.Object.(*v1.DebugMode).Name)
+	})
+	t.Run("should fail with client that does not support watches", func(t *testing.T) {
+		// given
+		withoutWatch := struct{ ctrlclient.Client }{Client: newControllerRuntimeClient(t, interceptor.Funcs{})}
+		sut := NewForControllerRuntimeClient(withoutWatch, "ecosystem")
+
+		// when
+		_, err := sut.Watch(testCtx, metav1.ListOptions{})
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsMethodNotSupported(err))
+	})
+}
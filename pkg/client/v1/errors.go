@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"errors"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+var (
+	// ErrDebugModeNotFound is returned if the requested debugMode does not exist or was deleted while waiting for it.
+	ErrDebugModeNotFound = errors.New("debug mode not found")
+	// ErrAlreadyActive is returned if a debugMode should be created while one already exists.
+	ErrAlreadyActive = errors.New("debug mode is already active")
+	// ErrIllegalPhaseTransition is returned if the phase of a debugMode cannot transition to the requested phase.
+	// Use errors.As with *v1.IllegalPhaseTransitionError to get the phases.
+	ErrIllegalPhaseTransition = errors.New("illegal phase transition")
+	// ErrSingletonNameViolation is returned if the API server rejects a debugMode not named v1.SingletonName.
+	ErrSingletonNameViolation = errors.New("debug mode must be named " + v1.SingletonName)
+	// ErrExpired is returned if the API server rejects a debugMode because its deactivation time is not in the future.
+	ErrExpired = errors.New("debug mode is expired")
+)
+
+// debugModeError attaches a sentinel error to an error of a debugMode operation. The message is kept, and errors.As
+// still finds the underlying error, e.g. the API status error.
+type debugModeError struct {
+	sentinel error
+	err      error
+}
+
+func (e *debugModeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *debugModeError) Unwrap() []error {
+	return []error{e.sentinel, e.err}
+}
+
+// wrapError attaches the matching sentinel error of this package to err, so callers can check it with errors.Is.
+// Errors that already carry a sentinel or do not match any are returned unchanged.
+func wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var alreadyWrapped *debugModeError
+	if errors.As(err, &alreadyWrapped) {
+		return err
+	}
+
+	sentinel := sentinelFor(err)
+	if sentinel == nil {
+		return err
+	}
+
+	return &debugModeError{sentinel: sentinel, err: err}
+}
+
+func sentinelFor(err error) error {
+	var illegalPhaseTransitionErr *v1.IllegalPhaseTransitionError
+	switch {
+	case errors.As(err, &illegalPhaseTransitionErr):
+		return ErrIllegalPhaseTransition
+	case apierrors.IsNotFound(err):
+		return ErrDebugModeNotFound
+	case apierrors.IsAlreadyExists(err):
+		return ErrAlreadyActive
+	case hasInvalidCause(err, v1.SingletonNameViolationMessage):
+		return ErrSingletonNameViolation
+	case hasInvalidCause(err, v1.DeactivationTimeNotInFutureMessage):
+		return ErrExpired
+	default:
+		return nil
+	}
+}
+
+// hasInvalidCause reports whether err is an invalid error with a cause containing the given message.
+func hasInvalidCause(err error, message string) bool {
+	if !apierrors.IsInvalid(err) {
+		return false
+	}
+
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return false
+	}
+
+	for _, cause := range statusErr.Status().Details.Causes {
+		if strings.Contains(cause.Message, message) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/webhook"
+)
+
+func Test_wrapError(t *testing.T) {
+	groupResource := v1.GroupVersion.WithResource("debugmodes").GroupResource()
+	groupKind := v1.GroupVersion.WithKind("DebugMode").GroupKind()
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "not found", err: apierrors.NewNotFound(groupResource, "debug-mode"), want: ErrDebugModeNotFound},
+		{name: "already exists", err: apierrors.NewAlreadyExists(groupResource, "debug-mode"), want: ErrAlreadyActive},
+		{name: "illegal phase transition", err: fmt.Errorf("failed: %w", &v1.IllegalPhaseTransitionError{From: v1.DebugModeStatusCompleted, To: v1.DebugModeStatusSet}), want: ErrIllegalPhaseTransition},
+		{name: "singleton name", err: apierrors.NewInvalid(groupKind, "other", field.ErrorList{field.Invalid(field.NewPath(""), "object", v1.SingletonNameViolationMessage)}), want: ErrSingletonNameViolation},
+		{name: "expired", err: apierrors.NewInvalid(groupKind, "debug-mode", field.ErrorList{field.Invalid(field.NewPath("spec", "deactivateTimestamp"), "2024-01-01T00:00:00Z", v1.DeactivationTimeNotInFutureMessage)}), want: ErrExpired},
+		{name: "other invalid", err: apierrors.NewInvalid(groupKind, "debug-mode", field.ErrorList{field.Required(field.NewPath("spec"), "")}), want: nil},
+		{name: "other error", err: assert.AnError, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			err := wrapError(tt.err)
+
+			// then
+			assert.Equal(t, tt.err.Error(), err.Error())
+			assert.ErrorIs(t, err, tt.err)
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.Same(t, tt.err, err)
+			}
+		})
+	}
+	t.Run("should detect expired debugMode rejected by validating webhook", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: v1.SingletonName, Namespace: "ecosystem"},
+			Spec:       v1.DebugModeSpec{TargetLogLevel: v1.LogLevelDebug, DeactivateTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+		}
+		_, validationErr := webhook.NewDebugModeValidator().ValidateCreate(testCtx, debugMode)
+		require.Error(t, validationErr)
+
+		// when
+		err := wrapError(validationErr)
+
+		// then
+		assert.ErrorIs(t, err, ErrExpired)
+	})
+	t.Run("should keep nil", func(t *testing.T) {
+		assert.NoError(t, wrapError(nil))
+	})
+	t.Run("should not wrap twice", func(t *testing.T) {
+		// given
+		err := wrapError(apierrors.NewNotFound(groupResource, "debug-mode"))
+
+		// when
+		result := wrapError(fmt.Errorf("failed to get debugMode: %w", err))
+
+		// then
+		assert.ErrorIs(t, result, ErrDebugModeNotFound)
+		var wrapped *debugModeError
+		require.ErrorAs(t, result, &wrapped)
+		assert.Same(t, err, wrapped)
+	})
+}
+
+func Test_helperClient_sentinelErrors(t *testing.T) {
+	t.Run("should return ErrDebugModeNotFound from plain operations and helpers", func(t *testing.T) {
+		// given
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}), "ecosystem")
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode"}}
+
+		// when
+		_, getErr := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		_, finalizerErr := sut.AddFinalizer(testCtx, debugMode, "my-finalizer")
+		_, conditionErr := sut.SetCondition(testCtx, debugMode, metav1.Condition{Type: v1.ConditionReady, Status: metav1.ConditionTrue})
+		deleteErr := sut.DeleteSingleton(testCtx, metav1.DeleteOptions{})
+
+		// then
+		for _, err := range []error{getErr, finalizerErr, conditionErr, deleteErr} {
+			assert.ErrorIs(t, err, ErrDebugModeNotFound)
+			assert.True(t, apierrors.IsNotFound(err))
+		}
+	})
+	t.Run("should return ErrAlreadyActive on create of existing debugMode", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode), "ecosystem")
+
+		// when
+		_, err := sut.Create(testCtx, &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode"}}, metav1.CreateOptions{})
+
+		// then
+		assert.ErrorIs(t, err, ErrAlreadyActive)
+		assert.True(t, apierrors.IsAlreadyExists(err))
+	})
+	t.Run("should return ErrIllegalPhaseTransition", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusCompleted}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode), "ecosystem")
+
+		// when
+		_, err := sut.UpdateStatusDebugModeSet(testCtx, debugMode.DeepCopy())
+
+		// then
+		assert.ErrorIs(t, err, ErrIllegalPhaseTransition)
+		var transitionErr *v1.IllegalPhaseTransitionError
+		require.ErrorAs(t, err, &transitionErr)
+		assert.Equal(t, v1.DebugModeStatusCompleted, transitionErr.From)
+	})
+	t.Run("should return ErrExpired if the webhook rejects the deactivation time", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{
+			Update: func(_ context.Context, _ ctrlclient.WithWatch, obj ctrlclient.Object, _ ...ctrlclient.UpdateOption) error {
+				return apierrors.NewInvalid(v1.GroupVersion.WithKind("DebugMode").GroupKind(), obj.GetName(),
+					field.ErrorList{field.Invalid(field.NewPath("spec", "duration"), "2024-01-01T00:00:00Z", v1.DeactivationTimeNotInFutureMessage)})
+			},
+		}, debugMode), "ecosystem")
+
+		// when
+		_, err := sut.EnsureSingleton(testCtx, v1.DebugModeSpec{Duration: &metav1.Duration{}})
+
+		// then
+		assert.ErrorIs(t, err, ErrExpired)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.False(t, errors.Is(err, ErrSingletonNameViolation))
+	})
+}
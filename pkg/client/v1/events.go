@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+// Reasons of the events recorded for phase transitions if the client is configured with WithEventRecorder.
+const (
+	// EventReasonDebugModeSet is recorded when the debug log levels are set.
+	EventReasonDebugModeSet = "DebugModeSet"
+	// EventReasonWaitForRollback is recorded when the deactivation time is reached and the rollback is pending.
+	EventReasonWaitForRollback = "WaitingForRollback"
+	// EventReasonRollback is recorded when the original log levels are being restored.
+	EventReasonRollback = "RollingBack"
+	// EventReasonCompleted is recorded when the original log levels are restored.
+	EventReasonCompleted = "Completed"
+	// EventReasonFailed is recorded as warning when the debug mode failed.
+	EventReasonFailed = "Failed"
+)
+
+var eventReasons = map[v1.StatusPhase]string{
+	v1.DebugModeStatusSet:             EventReasonDebugModeSet,
+	v1.DebugModeStatusWaitForRollback: EventReasonWaitForRollback,
+	v1.DebugModeStatusRollback:        EventReasonRollback,
+	v1.DebugModeStatusCompleted:       EventReasonCompleted,
+	v1.DebugModeStatusFailed:          EventReasonFailed,
+}
+
+// EventReason returns the reason of the event recorded for a transition to the given phase.
+// Unknown phases are returned as they are.
+func EventReason(phase v1.StatusPhase) string {
+	if reason, ok := eventReasons[phase]; ok {
+		return reason
+	}
+
+	return string(phase)
+}
+
+// recordTransition records an event for the phase transition of the debugMode, if an event recorder is configured.
+// Staying in the same phase is not recorded.
+func (client *helperClient) recordTransition(debugMode *v1.DebugMode, from, to v1.StatusPhase) {
+	if client.eventRecorder == nil || from == to {
+		return
+	}
+
+	eventType := corev1.EventTypeNormal
+	if to == v1.DebugModeStatusFailed {
+		eventType = corev1.EventTypeWarning
+	}
+
+	message := fmt.Sprintf("Debug mode changed phase from %q to %q", from, to)
+	if from == "" {
+		message = fmt.Sprintf("Debug mode changed phase to %q", to)
+	}
+
+	client.eventRecorder.Event(debugMode, eventType, EventReason(to), message)
+}
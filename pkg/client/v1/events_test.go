@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+// recordedEvents returns the events recorded so far by the fake recorder.
+func recordedEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestWithEventRecorder(t *testing.T) {
+	t.Run("should record timeline of phase transitions", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		recorder := record.NewFakeRecorder(10)
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode), "ecosystem", WithEventRecorder(recorder))
+
+		// when
+		set, err := sut.UpdateStatusDebugModeSet(testCtx, debugMode)
+		require.NoError(t, err)
+		setAgain, err := sut.UpdateStatusDebugModeSet(testCtx, set)
+		require.NoError(t, err)
+		waitForRollback, err := sut.UpdateStatusWaitForRollback(testCtx, setAgain)
+		require.NoError(t, err)
+		rollback, err := sut.UpdateStatusRollback(testCtx, waitForRollback)
+		require.NoError(t, err)
+		_, err = sut.UpdateStatusCompleted(testCtx, rollback)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, []string{
+			`Normal DebugModeSet Debug mode changed phase to "SetDebugMode"`,
+			`Normal WaitingForRollback Debug mode changed phase from "SetDebugMode" to "WaitForRollback"`,
+			`Normal RollingBack Debug mode changed phase from "WaitForRollback" to "Rollback"`,
+			`Normal Completed Debug mode changed phase from "Rollback" to "Completed"`,
+		}, recordedEvents(recorder))
+	})
+	t.Run("should record failure as warning", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusRollback}}
+		recorder := record.NewFakeRecorder(10)
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode), "ecosystem", WithEventRecorder(recorder))
+
+		// when
+		_, err := sut.UpdateStatusFailed(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{`Warning Failed Debug mode changed phase from "Rollback" to "Failed"`}, recordedEvents(recorder))
+	})
+	t.Run("should not record illegal transition", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusCompleted}}
+		recorder := record.NewFakeRecorder(10)
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode), "ecosystem", WithEventRecorder(recorder))
+
+		// when
+		_, err := sut.UpdateStatusRollback(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.ErrorIs(t, err, ErrIllegalPhaseTransition)
+		assert.Empty(t, recordedEvents(recorder))
+	})
+	t.Run("should not record without recorder", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode), "ecosystem")
+
+		// when
+		result, err := sut.UpdateStatusDebugModeSet(testCtx, debugMode)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusSet, result.Status.Phase)
+	})
+}
+
+func TestEventReason(t *testing.T) {
+	assert.Equal(t, EventReasonDebugModeSet, EventReason(v1.DebugModeStatusSet))
+	assert.Equal(t, EventReasonWaitForRollback, EventReason(v1.DebugModeStatusWaitForRollback))
+	assert.Equal(t, EventReasonRollback, EventReason(v1.DebugModeStatusRollback))
+	assert.Equal(t, EventReasonCompleted, EventReason(v1.DebugModeStatusCompleted))
+	assert.Equal(t, EventReasonFailed, EventReason(v1.DebugModeStatusFailed))
+	assert.Equal(t, "Unknown", EventReason("Unknown"))
+}
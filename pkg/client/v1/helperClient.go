@@ -0,0 +1,702 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	applyv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/applyconfigurations/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// helperClient implements the helper functions of the DebugModeInterface on top of the plain API operations
+// of a DebugModeResourceInterface, so they behave the same regardless of how the API is accessed.
+type helperClient struct {
+	DebugModeResourceInterface
+	backoff        wait.Backoff
+	isRetryable    func(error) bool
+	observer       Observer
+	tracerProvider trace.TracerProvider
+	tracer         trace.Tracer
+	eventRecorder  record.EventRecorder
+}
+
+// NewDebugModeInterface wraps the given plain API operations with the helper functions of the DebugModeInterface.
+func NewDebugModeInterface(resourceClient DebugModeResourceInterface, opts ...Option) DebugModeInterface {
+	client := &helperClient{
+		DebugModeResourceInterface: resourceClient,
+		backoff:                    DefaultBackoff,
+		isRetryable:                func(error) bool { return false },
+		observer:                   noopObserver{},
+		tracerProvider:             noop.NewTracerProvider(),
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	client.tracer = client.tracerProvider.Tracer(tracerName)
+
+	return client
+}
+
+// retry runs fn until it succeeds, returns an error that is neither a conflict nor retryable, the backoff is
+// exhausted or the context is done. The retries and conflicts are recorded on the span of the context.
+func (client *helperClient) retry(ctx context.Context, fn func() error) error {
+	span := trace.SpanFromContext(ctx)
+	attempts := 0
+	conflicts := 0
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, client.backoff, func(context.Context) (bool, error) {
+		if attempts > 0 {
+			client.observer.ObserveRetry()
+		}
+		attempts++
+
+		lastErr = fn()
+		switch {
+		case lastErr == nil:
+			return true, nil
+		case apierrors.IsConflict(lastErr):
+			conflicts++
+			client.observer.ObserveConflict()
+			span.AddEvent("conflict", trace.WithAttributes(attributeAttempt.Int(attempts-1)))
+			return false, nil
+		case client.isRetryable(lastErr):
+			return false, nil
+		default:
+			return false, lastErr
+		}
+	})
+	if err != lastErr && wait.Interrupted(err) && lastErr != nil {
+		// the backoff is exhausted or the context is done, the last error tells why the helper did not succeed
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = fmt.Errorf("%w: %w", ctxErr, lastErr)
+		} else {
+			err = lastErr
+		}
+	}
+
+	span.SetAttributes(attributeRetries.Int(attempts-1), attributeConflicts.Int(conflicts))
+	if conflicts > 0 {
+		outcome := conflictResolved
+		if apierrors.IsConflict(err) {
+			outcome = conflictUnresolved
+		}
+		span.SetAttributes(attributeConflictState.String(outcome))
+	}
+
+	return wrapError(err)
+}
+
+// The plain API operations are wrapped, so their errors carry the sentinel errors of this package as well.
+
+func (client *helperClient) Create(ctx context.Context, debugMode *v1.DebugMode, opts metav1.CreateOptions) (result *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "Create", debugMode.GetName())
+	defer func() { endSpan(span, err) }()
+
+	result, err = client.DebugModeResourceInterface.Create(ctx, debugMode, opts)
+	return result, wrapError(err)
+}
+
+func (client *helperClient) Update(ctx context.Context, debugMode *v1.DebugMode, opts metav1.UpdateOptions) (result *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "Update", debugMode.GetName())
+	defer func() { endSpan(span, err) }()
+
+	result, err = client.DebugModeResourceInterface.Update(ctx, debugMode, opts)
+	return result, wrapError(err)
+}
+
+func (client *helperClient) UpdateStatus(ctx context.Context, debugMode *v1.DebugMode, opts metav1.UpdateOptions) (result *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "UpdateStatus", debugMode.GetName())
+	defer func() { endSpan(span, err) }()
+
+	result, err = client.DebugModeResourceInterface.UpdateStatus(ctx, debugMode, opts)
+	return result, wrapError(err)
+}
+
+func (client *helperClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) (err error) {
+	ctx, span := client.startSpan(ctx, "Delete", name)
+	defer func() { endSpan(span, err) }()
+
+	return wrapError(client.DebugModeResourceInterface.Delete(ctx, name, opts))
+}
+
+func (client *helperClient) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) (err error) {
+	ctx, span := client.startSpan(ctx, "DeleteCollection", "")
+	defer func() { endSpan(span, err) }()
+
+	return wrapError(client.DebugModeResourceInterface.DeleteCollection(ctx, opts, listOpts))
+}
+
+func (client *helperClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (result *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "Get", name)
+	defer func() { endSpan(span, err) }()
+
+	result, err = client.DebugModeResourceInterface.Get(ctx, name, opts)
+	return result, wrapError(err)
+}
+
+func (client *helperClient) List(ctx context.Context, opts metav1.ListOptions) (result *v1.DebugModeList, err error) {
+	ctx, span := client.startSpan(ctx, "List", "")
+	defer func() { endSpan(span, err) }()
+
+	result, err = client.DebugModeResourceInterface.List(ctx, opts)
+	return result, wrapError(err)
+}
+
+func (client *helperClient) Watch(ctx context.Context, opts metav1.ListOptions) (result watch.Interface, err error) {
+	// the span only covers establishing the watch, not the time it is open
+	spanCtx, span := client.startSpan(ctx, "Watch", "")
+	defer func() { endSpan(span, err) }()
+
+	result, err = client.DebugModeResourceInterface.Watch(spanCtx, opts)
+	return result, wrapError(err)
+}
+
+func (client *helperClient) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "Patch", name)
+	defer func() { endSpan(span, err) }()
+
+	result, err = client.DebugModeResourceInterface.Patch(ctx, name, pt, data, opts, subresources...)
+	return result, wrapError(err)
+}
+
+func (client *helperClient) UpdateStatusCompleted(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error) {
+	debugMode, err := client.updateStatusWithRetry(ctx, "UpdateStatusCompleted", debugMode, v1.DebugModeStatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return debugMode, nil
+}
+
+func (client *helperClient) UpdateStatusDebugModeSet(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error) {
+	debugMode, err := client.updateStatusWithRetry(ctx, "UpdateStatusDebugModeSet", debugMode, v1.DebugModeStatusSet)
+	if err != nil {
+		return nil, err
+	}
+
+	return debugMode, nil
+}
+
+func (client *helperClient) UpdateStatusRollback(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error) {
+	debugMode, err := client.updateStatusWithRetry(ctx, "UpdateStatusRollback", debugMode, v1.DebugModeStatusRollback)
+	if err != nil {
+		return nil, err
+	}
+
+	return debugMode, nil
+}
+
+func (client *helperClient) UpdateStatusWaitForRollback(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error) {
+	debugMode, err := client.updateStatusWithRetry(ctx, "UpdateStatusWaitForRollback", debugMode, v1.DebugModeStatusWaitForRollback)
+	if err != nil {
+		return nil, err
+	}
+
+	return debugMode, nil
+}
+
+func (client *helperClient) UpdateStatusFailed(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error) {
+	debugMode, err := client.updateStatusWithRetry(ctx, "UpdateStatusFailed", debugMode, v1.DebugModeStatusFailed)
+	if err != nil {
+		return nil, err
+	}
+
+	return debugMode, nil
+}
+
+func (client *helperClient) updateStatusWithRetry(ctx context.Context, method string, debugMode *v1.DebugMode, targetStatus v1.StatusPhase) (result *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, method, debugMode.GetName(), attributePhaseTo.String(string(targetStatus)))
+	defer func() { endSpan(span, err) }()
+
+	var fromStatus v1.StatusPhase
+	result, err = client.patchStatusWithRetry(ctx, debugMode, func(updatedDebugMode *v1.DebugMode) (map[string]any, error) {
+		fromStatus = updatedDebugMode.Status.Phase
+		span.SetAttributes(attributePhaseFrom.String(string(fromStatus)))
+		// on retries, this checks against the latest phase, as the given debugMode may be outdated
+		if !v1.CanTransition(updatedDebugMode.Status.Phase, targetStatus) {
+			return nil, &v1.IllegalPhaseTransitionError{From: updatedDebugMode.Status.Phase, To: targetStatus}
+		}
+
+		// only patch the changed fields, so we do not lose other values from the Status object
+		// esp. a potentially set requeue time
+		updatedDebugMode.Status.Phase = targetStatus
+		statusPatch := map[string]any{"phase": targetStatus}
+
+		if targetStatus == v1.DebugModeStatusSet {
+			deactivationTime := updatedDebugMode.EffectiveDeactivationTime()
+			if !deactivationTime.IsZero() {
+				updatedDebugMode.Status.DeactivationTime = &deactivationTime
+				statusPatch["deactivationTime"] = deactivationTime
+			}
+		}
+
+		return statusPatch, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client.recordTransition(result, fromStatus, targetStatus)
+	return result, nil
+}
+
+// patchStatusWithRetry applies modify to the given debugMode and sends the returned status fields as JSON merge patch
+// to the status subresource. The resourceVersion of the debugMode is sent as precondition, because the patched
+// conditions and the checked phase transition depend on its state. A debugMode without resourceVersion was not read
+// from the API server, so the latest version is fetched first. On conflicts and retryable errors, the latest version
+// is fetched, modify is reapplied and the patch is retried. An error returned by modify aborts the patch.
+func (client *helperClient) patchStatusWithRetry(ctx context.Context, debugMode *v1.DebugMode, modify func(*v1.DebugMode) (map[string]any, error)) (*v1.DebugMode, error) {
+	name := debugMode.GetName()
+	// modify must not change the object of the caller, which may be shared, e.g. by an informer cache
+	debugMode = debugMode.DeepCopy()
+	if debugMode.GetResourceVersion() == "" {
+		debugMode = nil
+	}
+	var resultDebugMode *v1.DebugMode
+	err := client.retry(ctx, func() error {
+		if debugMode == nil {
+			latestDebugMode, err := client.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			debugMode = latestDebugMode
+		}
+
+		statusPatch, err := modify(debugMode)
+		if err != nil {
+			return err
+		}
+
+		patch := map[string]any{"status": statusPatch}
+		if resourceVersion := debugMode.GetResourceVersion(); resourceVersion != "" {
+			patch["metadata"] = map[string]any{"resourceVersion": resourceVersion}
+		}
+
+		data, err := json.Marshal(patch)
+		if err != nil {
+			return fmt.Errorf("failed to marshal status patch of debugMode %s: %w", name, err)
+		}
+
+		resultDebugMode, err = client.Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{}, "status")
+		if err != nil {
+			// start over with the latest version, as the debugMode may have been changed or partly modified
+			debugMode = nil
+		}
+		return err
+	})
+
+	return resultDebugMode, err
+}
+
+// modifyStatusWithRetry applies modify to the latest version of the debugMode and updates its status.
+// The debugMode is fetched again and modify is reapplied if the update runs into a conflict or a retryable error.
+// An error returned by modify aborts the update.
+func (client *helperClient) modifyStatusWithRetry(ctx context.Context, debugMode *v1.DebugMode, modify func(*v1.DebugMode) error) (*v1.DebugMode, error) {
+	return client.modifyWithRetry(ctx, debugMode.GetName(), nil, modify, client.UpdateStatus)
+}
+
+// modifyWithRetry applies modify to the given debugMode and writes it with update. If the debugMode is nil or the
+// write runs into a conflict or a retryable error, the latest version is fetched, modify is reapplied and the write
+// is retried.
+// An error returned by modify aborts the update.
+func (client *helperClient) modifyWithRetry(ctx context.Context, name string, debugMode *v1.DebugMode, modify func(*v1.DebugMode) error,
+	update func(context.Context, *v1.DebugMode, metav1.UpdateOptions) (*v1.DebugMode, error)) (*v1.DebugMode, error) {
+	// modify must not change the object of the caller, which may be shared, e.g. by an informer cache
+	debugMode = debugMode.DeepCopy()
+	var resultDebugMode *v1.DebugMode
+	err := client.retry(ctx, func() error {
+		if debugMode == nil {
+			latestDebugMode, err := client.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			debugMode = latestDebugMode
+		}
+
+		err := modify(debugMode)
+		if err != nil {
+			return err
+		}
+
+		resultDebugMode, err = update(ctx, debugMode, metav1.UpdateOptions{})
+		if err != nil {
+			// start over with the latest version, as the debugMode may have been changed or partly modified
+			debugMode = nil
+		}
+		return err
+	})
+
+	return resultDebugMode, err
+}
+
+func (client *helperClient) Apply(ctx context.Context, debugMode *applyv1.DebugModeApplyConfiguration, opts metav1.ApplyOptions) (*v1.DebugMode, error) {
+	return client.apply(ctx, debugMode, opts)
+}
+
+func (client *helperClient) ApplyStatus(ctx context.Context, debugMode *applyv1.DebugModeApplyConfiguration, opts metav1.ApplyOptions) (*v1.DebugMode, error) {
+	return client.apply(ctx, debugMode, opts, "status")
+}
+
+func (client *helperClient) apply(ctx context.Context, debugMode *applyv1.DebugModeApplyConfiguration, opts metav1.ApplyOptions, subresources ...string) (*v1.DebugMode, error) {
+	if debugMode == nil {
+		return nil, fmt.Errorf("debugMode provided to apply must not be nil")
+	}
+	if debugMode.ObjectMetaApplyConfiguration == nil || debugMode.Name == nil {
+		return nil, fmt.Errorf("debugMode.Name must be provided to apply")
+	}
+
+	data, err := json.Marshal(debugMode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal apply configuration of debugMode %s: %w", *debugMode.Name, err)
+	}
+
+	return client.Patch(ctx, *debugMode.Name, types.ApplyPatchType, data, opts.ToPatchOptions(), subresources...)
+}
+
+func (client *helperClient) ListAll(ctx context.Context, opts metav1.ListOptions) (_ *v1.DebugModeList, err error) {
+	ctx, span := client.startSpan(ctx, "ListAll", "")
+	defer func() { endSpan(span, err) }()
+
+	result := &v1.DebugModeList{}
+	for {
+		chunk, err := client.List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list debugModes: %w", err)
+		}
+
+		result.Items = append(result.Items, chunk.Items...)
+		// all chunks are served from the snapshot of the first request, so they share the same resource version
+		result.TypeMeta = chunk.TypeMeta
+		result.ResourceVersion = chunk.ResourceVersion
+		if chunk.Continue == "" {
+			return result, nil
+		}
+
+		opts.Continue = chunk.Continue
+		// the API server rejects a resource version together with a continue token, which already pins the snapshot
+		opts.ResourceVersion = ""
+		opts.ResourceVersionMatch = ""
+	}
+}
+
+func (client *helperClient) AddFinalizer(ctx context.Context, debugMode *v1.DebugMode, finalizer string) (_ *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "AddFinalizer", debugMode.GetName(), attributeFinalizer.String(finalizer))
+	defer func() { endSpan(span, err) }()
+
+	result, err := client.modifyWithRetry(ctx, debugMode.GetName(), debugMode, func(updatedDebugMode *v1.DebugMode) error {
+		controllerutil.AddFinalizer(updatedDebugMode, finalizer)
+		return nil
+	}, client.Update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add finalizer %s to debugMode: %w", finalizer, err)
+	}
+
+	return result, nil
+}
+
+func (client *helperClient) RemoveFinalizer(ctx context.Context, debugMode *v1.DebugMode, finalizer string) (_ *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "RemoveFinalizer", debugMode.GetName(), attributeFinalizer.String(finalizer))
+	defer func() { endSpan(span, err) }()
+
+	result, err := client.modifyWithRetry(ctx, debugMode.GetName(), debugMode, func(updatedDebugMode *v1.DebugMode) error {
+		controllerutil.RemoveFinalizer(updatedDebugMode, finalizer)
+		return nil
+	}, client.Update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove finalizer %s from debugMode: %w", finalizer, err)
+	}
+
+	return result, nil
+}
+
+func (client *helperClient) AddOrUpdateLogLevelsSet(ctx context.Context, debugMode *v1.DebugMode, set bool, msg string, reason string) (_ *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "AddOrUpdateLogLevelsSet", debugMode.GetName(), attributeCondition.String(v1.ConditionLogLevelSet))
+	defer func() { endSpan(span, err) }()
+
+	conditionStatus := metav1.ConditionFalse
+	if set == true {
+		conditionStatus = metav1.ConditionTrue
+	}
+
+	if reason == "" {
+		reason = "Initialized"
+	}
+
+	if msg == "" {
+		msg = "Condition set to initialized"
+	}
+
+	newCondition := metav1.Condition{
+		Type:               v1.ConditionLogLevelSet,
+		Status:             conditionStatus,
+		Reason:             reason,
+		Message:            msg,
+		LastTransitionTime: metav1.Now(),
+	}
+
+	result, err := client.setCondition(ctx, debugMode, newCondition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add or update condition %s to debugMode: %w", newCondition.Type, err)
+	}
+
+	return result, nil
+}
+
+func (client *helperClient) SetCondition(ctx context.Context, debugMode *v1.DebugMode, condition metav1.Condition) (_ *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "SetCondition", debugMode.GetName(), attributeCondition.String(condition.Type))
+	defer func() { endSpan(span, err) }()
+
+	result, err := client.setCondition(ctx, debugMode, condition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set condition %s on debugMode: %w", condition.Type, err)
+	}
+
+	return result, nil
+}
+
+func (client *helperClient) setCondition(ctx context.Context, debugMode *v1.DebugMode, condition metav1.Condition) (*v1.DebugMode, error) {
+	// the condition reflects the generation the caller has seen, not the one fetched on a retry
+	if condition.ObservedGeneration == 0 {
+		condition.ObservedGeneration = debugMode.GetGeneration()
+	}
+
+	return client.patchStatusWithRetry(ctx, debugMode, func(updatedDebugMode *v1.DebugMode) (map[string]any, error) {
+		_ = meta.SetStatusCondition(&updatedDebugMode.Status.Conditions, condition)
+		return conditionsPatch(updatedDebugMode), nil
+	})
+}
+
+// conditionsPatch returns the status fields to patch the conditions. JSON merge patches replace lists as a whole,
+// so the complete list is sent; an empty list removes all conditions.
+func conditionsPatch(debugMode *v1.DebugMode) map[string]any {
+	conditions := debugMode.Status.Conditions
+	if conditions == nil {
+		conditions = []metav1.Condition{}
+	}
+
+	return map[string]any{"conditions": conditions}
+}
+
+func (client *helperClient) RemoveCondition(ctx context.Context, debugMode *v1.DebugMode, conditionType string) (_ *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "RemoveCondition", debugMode.GetName(), attributeCondition.String(conditionType))
+	defer func() { endSpan(span, err) }()
+
+	result, err := client.patchStatusWithRetry(ctx, debugMode, func(updatedDebugMode *v1.DebugMode) (map[string]any, error) {
+		_ = meta.RemoveStatusCondition(&updatedDebugMode.Status.Conditions, conditionType)
+		return conditionsPatch(updatedDebugMode), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove condition %s from debugMode: %w", conditionType, err)
+	}
+
+	return result, nil
+}
+
+func (client *helperClient) AppendError(ctx context.Context, debugMode *v1.DebugMode, entry v1.ErrorEntry) (_ *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "AppendError", debugMode.GetName())
+	defer func() { endSpan(span, err) }()
+
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = metav1.Now()
+	}
+
+	result, err := client.modifyStatusWithRetry(ctx, debugMode, func(updatedDebugMode *v1.DebugMode) error {
+		errorEntry := entry
+		if errorEntry.Phase == "" {
+			errorEntry.Phase = updatedDebugMode.Status.Phase
+		}
+
+		updatedDebugMode.Status.AppendError(errorEntry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to append error to debugMode: %w", err)
+	}
+
+	return result, nil
+}
+
+func (client *helperClient) UpdateTargetStatus(ctx context.Context, debugMode *v1.DebugMode, targetStatus v1.TargetStatus) (_ *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "UpdateTargetStatus", debugMode.GetName())
+	defer func() { endSpan(span, err) }()
+
+	result, err := client.modifyStatusWithRetry(ctx, debugMode, func(updatedDebugMode *v1.DebugMode) error {
+		updatedDebugMode.Status.SetTargetStatus(targetStatus)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update status of target %s/%s of debugMode: %w", targetStatus.Kind, targetStatus.Name, err)
+	}
+
+	return result, nil
+}
+
+func (client *helperClient) GetSingleton(ctx context.Context, opts metav1.GetOptions) (*v1.DebugMode, error) {
+	return client.Get(ctx, v1.SingletonName, opts)
+}
+
+func (client *helperClient) EnsureSingleton(ctx context.Context, spec v1.DebugModeSpec) (_ *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "EnsureSingleton", v1.SingletonName)
+	defer func() { endSpan(span, err) }()
+
+	existing, err := client.Get(ctx, v1.SingletonName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: v1.SingletonName}, Spec: spec}
+		result, createErr := client.Create(ctx, debugMode, metav1.CreateOptions{})
+		if createErr != nil {
+			return nil, fmt.Errorf("failed to create debugMode %s: %w", v1.SingletonName, createErr)
+		}
+
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debugMode %s: %w", v1.SingletonName, err)
+	}
+
+	result, err := client.modifyWithRetry(ctx, v1.SingletonName, existing, func(updatedDebugMode *v1.DebugMode) error {
+		updatedDebugMode.Spec = *spec.DeepCopy()
+		return nil
+	}, client.Update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update debugMode %s: %w", v1.SingletonName, err)
+	}
+
+	return result, nil
+}
+
+func (client *helperClient) UpdateWithRetry(ctx context.Context, name string, modify func(*v1.DebugMode) error) (_ *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "UpdateWithRetry", name)
+	defer func() { endSpan(span, err) }()
+
+	var modifyErr error
+	result, err := client.modifyWithRetry(ctx, name, nil, func(debugMode *v1.DebugMode) error {
+		modifyErr = modify(debugMode)
+		return modifyErr
+	}, client.Update)
+	if modifyErr != nil {
+		return nil, modifyErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update debugMode %s: %w", name, err)
+	}
+
+	return result, nil
+}
+
+func (client *helperClient) DeleteSingleton(ctx context.Context, opts metav1.DeleteOptions) error {
+	return client.Delete(ctx, v1.SingletonName, opts)
+}
+
+func (client *helperClient) WaitForPhase(ctx context.Context, name string, phases ...v1.StatusPhase) (_ *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "WaitForPhase", name)
+	defer func() { endSpan(span, err) }()
+
+	return client.waitFor(ctx, name, func(debugMode *v1.DebugMode) (bool, error) {
+		return slices.Contains(phases, debugMode.Status.Phase), nil
+	})
+}
+
+func (client *helperClient) WaitForCondition(ctx context.Context, name string, conditionType string, status metav1.ConditionStatus) (_ *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "WaitForCondition", name, attributeCondition.String(conditionType))
+	defer func() { endSpan(span, err) }()
+
+	return client.waitFor(ctx, name, func(debugMode *v1.DebugMode) (bool, error) {
+		return meta.IsStatusConditionPresentAndEqual(debugMode.Status.Conditions, conditionType, status), nil
+	})
+}
+
+func (client *helperClient) WaitFor(ctx context.Context, name string, done func(*v1.DebugMode) (bool, error)) (_ *v1.DebugMode, err error) {
+	ctx, span := client.startSpan(ctx, "WaitFor", name)
+	defer func() { endSpan(span, err) }()
+
+	return client.waitFor(ctx, name, done)
+}
+
+func (client *helperClient) waitFor(ctx context.Context, name string, done func(*v1.DebugMode) (bool, error)) (*v1.DebugMode, error) {
+	// the error of done ends the wait like a reached state and is returned unchanged
+	var doneErr error
+	finished := func(debugMode *v1.DebugMode) bool {
+		var isDone bool
+		isDone, doneErr = done(debugMode)
+		return isDone || doneErr != nil
+	}
+
+	for {
+		debugMode, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get debugMode %s: %w", name, err)
+		}
+
+		if !finished(debugMode) {
+			debugMode, err = client.watchUntil(ctx, debugMode, finished)
+			if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+				// the resource version is too old to resume the watch, so start over with the latest version
+				continue
+			}
+			if err != nil {
+				return nil, wrapError(err)
+			}
+		}
+
+		if doneErr != nil {
+			return nil, doneErr
+		}
+		return debugMode, nil
+	}
+}
+
+func (client *helperClient) watchUntil(ctx context.Context, debugMode *v1.DebugMode, done func(*v1.DebugMode) bool) (*v1.DebugMode, error) {
+	name := debugMode.Name
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	// the retry watcher reconnects with the last seen resource version if the connection is closed
+	watcher, err := watchtools.NewRetryWatcherWithContext(ctx, debugMode.ResourceVersion, &cache.ListWatch{
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return client.Watch(ctx, options)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch debugMode %s: %w", name, err)
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to wait for debugMode %s: %w", name, ctx.Err())
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil, fmt.Errorf("failed to wait for debugMode %s: watch closed unexpectedly", name)
+			}
+
+			switch event.Type {
+			case watch.Error:
+				return nil, fmt.Errorf("failed to watch debugMode %s: %w", name, apierrors.FromObject(event.Object))
+			case watch.Deleted:
+				return nil, fmt.Errorf("failed to wait for debugMode %s: %w", name,
+					apierrors.NewNotFound(v1.GroupVersion.WithResource("debugmodes").GroupResource(), name))
+			case watch.Added, watch.Modified:
+				updatedDebugMode, ok := event.Object.(*v1.DebugMode)
+				if ok && updatedDebugMode.Name == name && done(updatedDebugMode) {
+					return updatedDebugMode, nil
+				}
+			}
+		}
+	}
+}
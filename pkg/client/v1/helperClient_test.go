@@ -0,0 +1,1015 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	applyv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/applyconfigurations/api/v1"
+)
+
+func Test_helperClient_Apply(t *testing.T) {
+	t.Run("should send apply patch", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodPatch, request.Method)
+			assert.Equal(t, "/apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/debug-mode", request.URL.Path)
+			assert.Equal(t, string(types.ApplyPatchType), request.Header.Get("Content-Type"))
+			assert.Equal(t, "fieldManager=ui&force=true", request.URL.RawQuery)
+
+			bytes, err := io.ReadAll(request.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"apiVersion":"k8s.cloudogu.com/v1","kind":"DebugMode","metadata":{"name":"debug-mode","namespace":"test"},"spec":{"targetLogLevel":"DEBUG"}}`, string(bytes))
+
+			result, err := json.Marshal(v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode"}, Spec: v1.DebugModeSpec{TargetLogLevel: "DEBUG"}})
+			require.NoError(t, err)
+			writer.Header().Add("content-type", "application/json")
+			_, err = writer.Write(result)
+			require.NoError(t, err)
+		}))
+
+		client, err := NewForConfig(&rest.Config{Host: server.URL})
+		require.NoError(t, err)
+		sClient := client.DebugMode("test")
+
+		applyConfig := applyv1.DebugMode("debug-mode", "test").
+			WithSpec(applyv1.DebugModeSpec().WithTargetLogLevel("DEBUG"))
+
+		// when
+		result, err := sClient.Apply(testCtx, applyConfig, metav1.ApplyOptions{FieldManager: "ui", Force: true})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "DEBUG", result.Spec.TargetLogLevel)
+	})
+	t.Run("should fail without configuration", func(t *testing.T) {
+		// given
+		client, err := NewForConfig(&rest.Config{})
+		require.NoError(t, err)
+		sClient := client.DebugMode("test")
+
+		// when
+		_, err = sClient.Apply(testCtx, nil, metav1.ApplyOptions{FieldManager: "ui"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "debugMode provided to apply must not be nil")
+	})
+	t.Run("should fail without name", func(t *testing.T) {
+		// given
+		client, err := NewForConfig(&rest.Config{})
+		require.NoError(t, err)
+		sClient := client.DebugMode("test")
+
+		// when
+		_, err = sClient.Apply(testCtx, &applyv1.DebugModeApplyConfiguration{}, metav1.ApplyOptions{FieldManager: "ui"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "debugMode.Name must be provided to apply")
+	})
+}
+
+func Test_helperClient_ApplyStatus(t *testing.T) {
+	t.Run("should send apply patch to status subresource", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodPatch, request.Method)
+			assert.Equal(t, "/apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/debug-mode/status", request.URL.Path)
+			assert.Equal(t, string(types.ApplyPatchType), request.Header.Get("Content-Type"))
+
+			bytes, err := io.ReadAll(request.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"apiVersion":"k8s.cloudogu.com/v1","kind":"DebugMode","metadata":{"name":"debug-mode","namespace":"test"},"status":{"phase":"Rollback"}}`, string(bytes))
+
+			writer.Header().Add("content-type", "application/json")
+			_, err = writer.Write([]byte(`{"status":{"phase":"Rollback"}}`))
+			require.NoError(t, err)
+		}))
+
+		client, err := NewForConfig(&rest.Config{Host: server.URL})
+		require.NoError(t, err)
+		sClient := client.DebugMode("test")
+
+		applyConfig := applyv1.DebugMode("debug-mode", "test").
+			WithStatus(applyv1.DebugModeStatus().WithPhase(v1.DebugModeStatusRollback))
+
+		// when
+		result, err := sClient.ApplyStatus(testCtx, applyConfig, metav1.ApplyOptions{FieldManager: "operator"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusRollback, result.Status.Phase)
+	})
+}
+
+func Test_helperClient_patchStatus(t *testing.T) {
+	// newServer records the requests and answers them in order with the given responses.
+	type response struct {
+		code      int
+		debugMode *v1.DebugMode
+	}
+	newServer := func(t *testing.T, requests *[]string, responses ...response) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			bytes, err := io.ReadAll(request.Body)
+			require.NoError(t, err)
+			*requests = append(*requests, request.Method+" "+request.URL.Path+" "+string(bytes))
+
+			require.NotEmpty(t, responses, "unexpected request")
+			resp := responses[0]
+			responses = responses[1:]
+			if resp.code != http.StatusOK {
+				writer.WriteHeader(resp.code)
+				return
+			}
+
+			result, err := json.Marshal(resp.debugMode)
+			require.NoError(t, err)
+			writer.Header().Add("content-type", "application/json")
+			_, err = writer.Write(result)
+			require.NoError(t, err)
+		}))
+	}
+	deactivationTime := metav1.NewTime(time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC))
+	debugMode := &v1.DebugMode{
+		ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "test", ResourceVersion: "1"},
+		Spec:       v1.DebugModeSpec{DeactivateTimestamp: deactivationTime},
+	}
+
+	t.Run("should only patch phase and deactivation time", func(t *testing.T) {
+		// given
+		var requests []string
+		server := newServer(t, &requests, response{code: http.StatusOK, debugMode: debugMode})
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").UpdateStatusDebugModeSet(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			`PATCH /apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/debug-mode/status {"metadata":{"resourceVersion":"1"},"status":{"deactivationTime":"2025-09-01T12:00:00Z","phase":"SetDebugMode"}}`,
+		}, requests)
+	})
+	t.Run("should send resource version as precondition by default", func(t *testing.T) {
+		// given
+		var requests []string
+		server := newServer(t, &requests, response{code: http.StatusOK, debugMode: debugMode})
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").SetCondition(testCtx, debugMode.DeepCopy(), metav1.Condition{Type: v1.ConditionReady, Status: metav1.ConditionTrue, Reason: "Ready", LastTransitionTime: deactivationTime})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			`PATCH /apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/debug-mode/status {"metadata":{"resourceVersion":"1"},"status":{"conditions":[{"type":"Ready","status":"True","lastTransitionTime":"2025-09-01T12:00:00Z","reason":"Ready","message":""}]}}`,
+		}, requests)
+	})
+	t.Run("should patch latest version on conflict", func(t *testing.T) {
+		// given
+		latest := debugMode.DeepCopy()
+		latest.ResourceVersion = "2"
+		latest.Status.Phase = v1.DebugModeStatusSet
+		var requests []string
+		server := newServer(t, &requests,
+			response{code: http.StatusConflict},
+			response{code: http.StatusOK, debugMode: latest},
+			response{code: http.StatusOK, debugMode: latest},
+		)
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").UpdateStatusFailed(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			`PATCH /apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/debug-mode/status {"metadata":{"resourceVersion":"1"},"status":{"phase":"Failed"}}`,
+			`GET /apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/debug-mode `,
+			`PATCH /apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/debug-mode/status {"metadata":{"resourceVersion":"2"},"status":{"phase":"Failed"}}`,
+		}, requests)
+	})
+	t.Run("should recheck transition against latest version on conflict", func(t *testing.T) {
+		// given
+		latest := debugMode.DeepCopy()
+		latest.ResourceVersion = "2"
+		latest.Status.Phase = v1.DebugModeStatusCompleted
+		var requests []string
+		server := newServer(t, &requests,
+			response{code: http.StatusConflict},
+			response{code: http.StatusOK, debugMode: latest},
+		)
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").UpdateStatusDebugModeSet(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.Error(t, err)
+		var transitionErr *v1.IllegalPhaseTransitionError
+		require.ErrorAs(t, err, &transitionErr)
+		assert.Equal(t, v1.DebugModeStatusCompleted, transitionErr.From)
+		assert.Len(t, requests, 2)
+	})
+	t.Run("should check transition against latest version if resource version is missing", func(t *testing.T) {
+		// given
+		stored := debugMode.DeepCopy()
+		stored.Status.Phase = v1.DebugModeStatusRollback
+		completed := stored.DeepCopy()
+		completed.Status.Phase = v1.DebugModeStatusCompleted
+		var requests []string
+		server := newServer(t, &requests,
+			response{code: http.StatusOK, debugMode: stored},
+			response{code: http.StatusOK, debugMode: completed},
+		)
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff))
+		require.NoError(t, err)
+
+		// when
+		result, err := client.DebugMode("test").UpdateStatusCompleted(testCtx, &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode"}})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusCompleted, result.Status.Phase)
+		assert.Equal(t, []string{
+			`GET /apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/debug-mode `,
+			`PATCH /apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/debug-mode/status {"metadata":{"resourceVersion":"1"},"status":{"phase":"Completed"}}`,
+		}, requests)
+	})
+	t.Run("should keep stored conditions if resource version is missing", func(t *testing.T) {
+		// given
+		stored := debugMode.DeepCopy()
+		stored.Status.Conditions = []metav1.Condition{{Type: v1.ConditionDegraded, Status: metav1.ConditionTrue, Reason: "TargetFailed", LastTransitionTime: deactivationTime}}
+		var requests []string
+		server := newServer(t, &requests,
+			response{code: http.StatusOK, debugMode: stored},
+			response{code: http.StatusOK, debugMode: stored},
+		)
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").SetCondition(testCtx, &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode"}},
+			metav1.Condition{Type: v1.ConditionReady, Status: metav1.ConditionTrue, Reason: "Ready", LastTransitionTime: deactivationTime})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			`GET /apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/debug-mode `,
+			`PATCH /apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/debug-mode/status {"metadata":{"resourceVersion":"1"},"status":{"conditions":[{"type":"Degraded","status":"True","lastTransitionTime":"2025-09-01T12:00:00Z","reason":"TargetFailed","message":""},{"type":"Ready","status":"True","lastTransitionTime":"2025-09-01T12:00:00Z","reason":"Ready","message":""}]}}`,
+		}, requests)
+	})
+	t.Run("should send empty list when last condition is removed", func(t *testing.T) {
+		// given
+		withCondition := debugMode.DeepCopy()
+		withCondition.Status.Conditions = []metav1.Condition{{Type: v1.ConditionReady, Status: metav1.ConditionTrue}}
+		var requests []string
+		server := newServer(t, &requests, response{code: http.StatusOK, debugMode: debugMode})
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").RemoveCondition(testCtx, withCondition, v1.ConditionReady)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			`PATCH /apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/debug-mode/status {"metadata":{"resourceVersion":"1"},"status":{"conditions":[]}}`,
+		}, requests)
+	})
+}
+
+// concurrentStatusChange changes the status of the stored debugMode right before the first status patch is applied,
+// as if another client updated it between the read of the caller and the patch.
+func concurrentStatusChange(t *testing.T, change func(*v1.DebugMode)) interceptor.Funcs {
+	t.Helper()
+
+	changed := false
+	return interceptor.Funcs{
+		SubResourcePatch: func(ctx context.Context, client ctrlclient.Client, subResourceName string, obj ctrlclient.Object, patch ctrlclient.Patch, opts ...ctrlclient.SubResourcePatchOption) error {
+			if !changed {
+				changed = true
+				stored := &v1.DebugMode{}
+				require.NoError(t, client.Get(ctx, ctrlclient.ObjectKeyFromObject(obj), stored))
+				change(stored)
+				require.NoError(t, client.Status().Update(ctx, stored))
+			}
+			return client.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+		},
+	}
+}
+
+func Test_helperClient_concurrentStatusChanges(t *testing.T) {
+	debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+
+	t.Run("should keep condition set concurrently", func(t *testing.T) {
+		// given
+		c := newControllerRuntimeClient(t, concurrentStatusChange(t, func(stored *v1.DebugMode) {
+			meta.SetStatusCondition(&stored.Status.Conditions, metav1.Condition{Type: v1.ConditionDegraded, Status: metav1.ConditionTrue, Reason: "TargetFailed"})
+		}), debugMode.DeepCopy())
+		sut := NewForControllerRuntimeClient(c, "ecosystem", WithBackoff(testBackoff))
+		read, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+
+		// when
+		_, err = sut.SetCondition(testCtx, read, metav1.Condition{Type: v1.ConditionReady, Status: metav1.ConditionTrue, Reason: "Ready"})
+
+		// then
+		require.NoError(t, err)
+		stored, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.True(t, meta.IsStatusConditionTrue(stored.Status.Conditions, v1.ConditionDegraded))
+		assert.True(t, meta.IsStatusConditionTrue(stored.Status.Conditions, v1.ConditionReady))
+	})
+	t.Run("should keep condition changed concurrently when removing another one", func(t *testing.T) {
+		// given
+		withConditions := debugMode.DeepCopy()
+		withConditions.Status.Conditions = []metav1.Condition{
+			{Type: v1.ConditionReady, Status: metav1.ConditionTrue, Reason: "Ready", LastTransitionTime: metav1.Now()},
+			{Type: v1.ConditionDegraded, Status: metav1.ConditionFalse, Reason: "AllTargetsSet", LastTransitionTime: metav1.Now()},
+		}
+		c := newControllerRuntimeClient(t, concurrentStatusChange(t, func(stored *v1.DebugMode) {
+			meta.SetStatusCondition(&stored.Status.Conditions, metav1.Condition{Type: v1.ConditionDegraded, Status: metav1.ConditionTrue, Reason: "TargetFailed"})
+		}), withConditions)
+		sut := NewForControllerRuntimeClient(c, "ecosystem", WithBackoff(testBackoff))
+		read, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+
+		// when
+		_, err = sut.RemoveCondition(testCtx, read, v1.ConditionReady)
+
+		// then
+		require.NoError(t, err)
+		stored, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Nil(t, meta.FindStatusCondition(stored.Status.Conditions, v1.ConditionReady))
+		assert.True(t, meta.IsStatusConditionTrue(stored.Status.Conditions, v1.ConditionDegraded))
+	})
+	t.Run("should check transition against phase changed concurrently", func(t *testing.T) {
+		// given
+		set := debugMode.DeepCopy()
+		set.Status.Phase = v1.DebugModeStatusSet
+		c := newControllerRuntimeClient(t, concurrentStatusChange(t, func(stored *v1.DebugMode) {
+			stored.Status.Phase = v1.DebugModeStatusCompleted
+		}), set)
+		sut := NewForControllerRuntimeClient(c, "ecosystem", WithBackoff(testBackoff))
+		read, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+
+		// when
+		_, err = sut.UpdateStatusWaitForRollback(testCtx, read)
+
+		// then
+		require.ErrorIs(t, err, ErrIllegalPhaseTransition)
+		stored, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusCompleted, stored.Status.Phase)
+	})
+}
+
+func Test_helperClient_keepsGivenDebugMode(t *testing.T) {
+	debugMode := &v1.DebugMode{
+		ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem", Finalizers: []string{"other-finalizer"}},
+		Spec:       v1.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second))},
+	}
+	tests := []struct {
+		name   string
+		helper func(sut DebugModeInterface, given *v1.DebugMode) error
+	}{
+		{"UpdateStatusDebugModeSet", func(sut DebugModeInterface, given *v1.DebugMode) error {
+			_, err := sut.UpdateStatusDebugModeSet(testCtx, given)
+			return err
+		}},
+		{"SetCondition", func(sut DebugModeInterface, given *v1.DebugMode) error {
+			_, err := sut.SetCondition(testCtx, given, metav1.Condition{Type: v1.ConditionReady, Status: metav1.ConditionTrue, Reason: "Ready"})
+			return err
+		}},
+		{"AddFinalizer", func(sut DebugModeInterface, given *v1.DebugMode) error {
+			_, err := sut.AddFinalizer(testCtx, given, "my-finalizer")
+			return err
+		}},
+		{"RemoveFinalizer", func(sut DebugModeInterface, given *v1.DebugMode) error {
+			_, err := sut.RemoveFinalizer(testCtx, given, "other-finalizer")
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" should not modify the given debugMode", func(t *testing.T) {
+			// given
+			sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode.DeepCopy()), "ecosystem")
+			given, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+			require.NoError(t, err)
+			original := given.DeepCopy()
+
+			// when
+			err = tt.helper(sut, given)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, original, given)
+		})
+	}
+}
+
+func Test_helperClient_UpdateWithRetry(t *testing.T) {
+	debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+
+	t.Run("should modify latest version on conflict", func(t *testing.T) {
+		// given
+		c := newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode.DeepCopy())
+		sut := NewForControllerRuntimeClient(c, "ecosystem", WithBackoff(testBackoff))
+		stored, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+		modifications := 0
+
+		// when
+		result, err := sut.UpdateWithRetry(testCtx, "debug-mode", func(latest *v1.DebugMode) error {
+			modifications++
+			if modifications == 1 {
+				// another client changes the debugMode before our update
+				stored.Spec.TargetLogLevel = v1.LogLevelInfo
+				_, err := sut.Update(testCtx, stored, metav1.UpdateOptions{})
+				require.NoError(t, err)
+			}
+			latest.Labels = map[string]string{"extended": "true"}
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, modifications)
+		assert.Equal(t, v1.LogLevelInfo, result.Spec.TargetLogLevel)
+		assert.Equal(t, "true", result.Labels["extended"])
+	})
+	t.Run("should return error of modify unchanged", func(t *testing.T) {
+		// given
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode.DeepCopy()), "ecosystem")
+
+		// when
+		_, err := sut.UpdateWithRetry(testCtx, "debug-mode", func(*v1.DebugMode) error {
+			return assert.AnError
+		})
+
+		// then
+		assert.Same(t, assert.AnError, err)
+	})
+	t.Run("should fail if debugMode does not exist", func(t *testing.T) {
+		// given
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}), "ecosystem")
+
+		// when
+		_, err := sut.UpdateWithRetry(testCtx, "debug-mode", func(*v1.DebugMode) error {
+			return nil
+		})
+
+		// then
+		require.ErrorIs(t, err, ErrDebugModeNotFound)
+		assert.ErrorContains(t, err, "failed to update debugMode debug-mode")
+	})
+}
+
+func Test_helperClient_UpdateStatusRollback(t *testing.T) {
+	for _, phase := range []v1.StatusPhase{v1.DebugModeStatusSet, v1.DebugModeStatusFailed} {
+		t.Run("should roll back from "+string(phase), func(t *testing.T) {
+			// given
+			debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}, Status: v1.DebugModeStatus{Phase: phase}}
+			sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode), "ecosystem")
+
+			// when
+			result, err := sut.UpdateStatusRollback(testCtx, debugMode.DeepCopy())
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, v1.DebugModeStatusRollback, result.Status.Phase)
+		})
+	}
+}
+
+func Test_helperClient_AppendError(t *testing.T) {
+	t.Run("should append error with current phase and timestamp", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"},
+			Status:     v1.DebugModeStatus{Phase: v1.DebugModeStatusRollback},
+		}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode.DeepCopy()), "ecosystem")
+
+		// when
+		result, err := sut.AppendError(testCtx, debugMode, v1.ErrorEntry{Target: "dogu/cas", Message: "failed to restore log level", Retryable: true})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result.Status.ErrorEntries, 1)
+		entry := result.Status.ErrorEntries[0]
+		assert.Equal(t, "dogu/cas", entry.Target)
+		assert.Equal(t, v1.DebugModeStatusRollback, entry.Phase)
+		assert.Equal(t, "failed to restore log level", entry.Message)
+		assert.True(t, entry.Retryable)
+		assert.False(t, entry.Timestamp.IsZero())
+	})
+	t.Run("should keep entries appended concurrently", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode), "ecosystem", WithBackoff(testBackoff))
+		read, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+		_, err = sut.AppendError(testCtx, read, v1.ErrorEntry{Target: "dogu/cas", Message: "first"})
+		require.NoError(t, err)
+
+		// when
+		// the given debugMode is outdated, but the helper appends to the latest version
+		result, err := sut.AppendError(testCtx, read, v1.ErrorEntry{Target: "dogu/ldap", Message: "second"})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result.Status.ErrorEntries, 2)
+		assert.Equal(t, "first", result.Status.ErrorEntries[0].Message)
+		assert.Equal(t, "second", result.Status.ErrorEntries[1].Message)
+	})
+	t.Run("should return error if debug mode does not exist", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}), "ecosystem")
+
+		// when
+		_, err := sut.AppendError(testCtx, debugMode, v1.ErrorEntry{Message: "failed"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to append error to debugMode")
+		assert.ErrorIs(t, err, ErrDebugModeNotFound)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func Test_helperClient_UpdateTargetStatus(t *testing.T) {
+	t.Run("should update single target and keep others", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"},
+			Status: v1.DebugModeStatus{Targets: []v1.TargetStatus{
+				{Kind: v1.TargetKindDogu, Name: "cas", State: v1.TargetStatePending, LastTransitionTime: metav1.Now()},
+				{Kind: v1.TargetKindDogu, Name: "ldap", State: v1.TargetStatePending, LastTransitionTime: metav1.Now()},
+			}},
+		}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode.DeepCopy()), "ecosystem")
+
+		// when
+		result, err := sut.UpdateTargetStatus(testCtx, debugMode, v1.TargetStatus{
+			Kind: v1.TargetKindDogu, Name: "cas", OriginalLogLevel: v1.LogLevelWarn, AppliedLogLevel: v1.LogLevelDebug, State: v1.TargetStateApplied,
+		})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result.Status.Targets, 2)
+		assert.Equal(t, v1.TargetStateApplied, result.Status.FindTargetStatus(v1.TargetKindDogu, "cas").State)
+		assert.Equal(t, v1.LogLevelWarn, result.Status.FindTargetStatus(v1.TargetKindDogu, "cas").OriginalLogLevel)
+		assert.Equal(t, v1.TargetStatePending, result.Status.FindTargetStatus(v1.TargetKindDogu, "ldap").State)
+	})
+	t.Run("should keep target updated concurrently", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode), "ecosystem", WithBackoff(testBackoff))
+		read, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+		_, err = sut.UpdateTargetStatus(testCtx, read, v1.TargetStatus{Kind: v1.TargetKindDogu, Name: "ldap", State: v1.TargetStateApplied})
+		require.NoError(t, err)
+
+		// when
+		// the given debugMode is outdated, but the helper updates the latest version
+		result, err := sut.UpdateTargetStatus(testCtx, read, v1.TargetStatus{Kind: v1.TargetKindDogu, Name: "cas", State: v1.TargetStateFailed})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result.Status.Targets, 2)
+		assert.Equal(t, v1.TargetStateApplied, result.Status.FindTargetStatus(v1.TargetKindDogu, "ldap").State)
+		assert.Equal(t, v1.TargetStateFailed, result.Status.FindTargetStatus(v1.TargetKindDogu, "cas").State)
+	})
+	t.Run("should return error if debug mode does not exist", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}), "ecosystem")
+
+		// when
+		_, err := sut.UpdateTargetStatus(testCtx, debugMode, v1.TargetStatus{Kind: v1.TargetKindDogu, Name: "cas", State: v1.TargetStateFailed})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to update status of target dogu/cas of debugMode")
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func Test_helperClient_SetCondition(t *testing.T) {
+	t.Run("should set condition with observed generation and keep others", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem", Generation: 3},
+			Status: v1.DebugModeStatus{Conditions: []metav1.Condition{
+				{Type: v1.ConditionLogLevelSet, Status: metav1.ConditionTrue, Reason: "LevelsApplied", LastTransitionTime: metav1.Now()},
+			}},
+		}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode.DeepCopy()), "ecosystem")
+
+		// when
+		result, err := sut.SetCondition(testCtx, debugMode, metav1.Condition{
+			Type: v1.ConditionDegraded, Status: metav1.ConditionTrue, Reason: "TargetFailed", Message: "dogu/cas failed",
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, result.Status.Conditions, 2)
+		condition := meta.FindStatusCondition(result.Status.Conditions, v1.ConditionDegraded)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, int64(3), condition.ObservedGeneration)
+		assert.False(t, condition.LastTransitionTime.IsZero())
+		assert.True(t, meta.IsStatusConditionTrue(result.Status.Conditions, v1.ConditionLogLevelSet))
+	})
+	t.Run("should keep given observed generation", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem", Generation: 3}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode.DeepCopy()), "ecosystem")
+
+		// when
+		result, err := sut.SetCondition(testCtx, debugMode, metav1.Condition{
+			Type: v1.ConditionReady, Status: metav1.ConditionFalse, Reason: "Pending", ObservedGeneration: 2,
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), meta.FindStatusCondition(result.Status.Conditions, v1.ConditionReady).ObservedGeneration)
+	})
+	t.Run("should return error if debug mode does not exist", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}), "ecosystem")
+
+		// when
+		_, err := sut.SetCondition(testCtx, debugMode, metav1.Condition{Type: v1.ConditionReady, Status: metav1.ConditionTrue, Reason: "Active"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to set condition Ready on debugMode")
+		assert.ErrorIs(t, err, ErrDebugModeNotFound)
+	})
+}
+
+func Test_helperClient_RemoveCondition(t *testing.T) {
+	t.Run("should remove condition", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"},
+			Status: v1.DebugModeStatus{Conditions: []metav1.Condition{
+				{Type: v1.ConditionDegraded, Status: metav1.ConditionTrue, Reason: "TargetFailed", LastTransitionTime: metav1.Now()},
+				{Type: v1.ConditionReady, Status: metav1.ConditionTrue, Reason: "Active", LastTransitionTime: metav1.Now()},
+			}},
+		}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode.DeepCopy()), "ecosystem")
+
+		// when
+		result, err := sut.RemoveCondition(testCtx, debugMode, v1.ConditionDegraded)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, meta.FindStatusCondition(result.Status.Conditions, v1.ConditionDegraded))
+		assert.NotNil(t, meta.FindStatusCondition(result.Status.Conditions, v1.ConditionReady))
+	})
+	t.Run("should return error if debug mode does not exist", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}), "ecosystem")
+
+		// when
+		_, err := sut.RemoveCondition(testCtx, debugMode, v1.ConditionDegraded)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to remove condition Degraded from debugMode")
+		assert.ErrorIs(t, err, ErrDebugModeNotFound)
+	})
+}
+
+func Test_helperClient_Singleton(t *testing.T) {
+	t.Run("should create singleton", func(t *testing.T) {
+		// given
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}), "ecosystem")
+
+		// when
+		result, err := sut.EnsureSingleton(testCtx, v1.DebugModeSpec{TargetLogLevel: v1.LogLevelDebug})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.SingletonName, result.Name)
+		stored, err := sut.GetSingleton(testCtx, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, v1.LogLevelDebug, stored.Spec.TargetLogLevel)
+	})
+	t.Run("should replace spec of existing singleton", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: v1.SingletonName, Namespace: "ecosystem", Finalizers: []string{"my-finalizer"}},
+			Spec:       v1.DebugModeSpec{TargetLogLevel: v1.LogLevelDebug, Targets: []v1.LogLevelTarget{{Kind: v1.TargetKindDogu, Name: "cas", LogLevel: v1.LogLevelDebug}}},
+		}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode), "ecosystem")
+
+		// when
+		result, err := sut.EnsureSingleton(testCtx, v1.DebugModeSpec{TargetLogLevel: v1.LogLevelInfo})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeSpec{TargetLogLevel: v1.LogLevelInfo}, result.Spec)
+		assert.Equal(t, []string{"my-finalizer"}, result.Finalizers)
+	})
+	t.Run("should fail to ensure singleton on get error", func(t *testing.T) {
+		// given
+		c := newControllerRuntimeClient(t, interceptor.Funcs{
+			Get: func(context.Context, ctrlclient.WithWatch, ctrlclient.ObjectKey, ctrlclient.Object, ...ctrlclient.GetOption) error {
+				return assert.AnError
+			},
+		})
+		sut := NewForControllerRuntimeClient(c, "ecosystem")
+
+		// when
+		_, err := sut.EnsureSingleton(testCtx, v1.DebugModeSpec{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get debugMode debug-mode")
+	})
+	t.Run("should delete singleton", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: v1.SingletonName, Namespace: "ecosystem"}}
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}, debugMode), "ecosystem")
+
+		// when
+		err := sut.DeleteSingleton(testCtx, metav1.DeleteOptions{})
+
+		// then
+		require.NoError(t, err)
+		_, err = sut.GetSingleton(testCtx, metav1.GetOptions{})
+		assert.ErrorIs(t, err, ErrDebugModeNotFound)
+	})
+}
+
+// interceptWatch serves the watches of the helpers with the given function instead of the stored debugModes.
+func interceptWatch(watchFunc func(c ctrlclient.WithWatch, opts metav1.ListOptions) (watch.Interface, error)) interceptor.Funcs {
+	return interceptor.Funcs{
+		Watch: func(_ context.Context, c ctrlclient.WithWatch, _ ctrlclient.ObjectList, opts ...ctrlclient.ListOption) (watch.Interface, error) {
+			listOptions := &ctrlclient.ListOptions{}
+			listOptions.ApplyOptions(opts)
+			return watchFunc(c, *listOptions.Raw)
+		},
+	}
+}
+
+func Test_helperClient_WaitForPhase(t *testing.T) {
+	newDebugMode := func(resourceVersion string, phase v1.StatusPhase) *v1.DebugMode {
+		return &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem", ResourceVersion: resourceVersion},
+			Status:     v1.DebugModeStatus{Phase: phase},
+		}
+	}
+	newWatcher := func(events ...watch.Event) *watch.FakeWatcher {
+		watcher := watch.NewFakeWithChanSize(len(events), false)
+		for _, event := range events {
+			watcher.Action(event.Type, event.Object)
+		}
+		return watcher
+	}
+
+	t.Run("should return immediately if phase is already reached", func(t *testing.T) {
+		// given
+		watches := 0
+		c := newControllerRuntimeClient(t, interceptWatch(func(ctrlclient.WithWatch, metav1.ListOptions) (watch.Interface, error) {
+			watches++
+			return newWatcher(), nil
+		}), newDebugMode("", v1.DebugModeStatusCompleted))
+		sut := NewForControllerRuntimeClient(c, "ecosystem")
+
+		// when
+		result, err := sut.WaitForPhase(testCtx, "debug-mode", v1.DebugModeStatusCompleted, v1.DebugModeStatusFailed)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusCompleted, result.Status.Phase)
+		assert.Zero(t, watches)
+	})
+	t.Run("should watch until phase is reached", func(t *testing.T) {
+		// given
+		var watchOptions metav1.ListOptions
+		c := newControllerRuntimeClient(t, interceptWatch(func(_ ctrlclient.WithWatch, opts metav1.ListOptions) (watch.Interface, error) {
+			watchOptions = opts
+			return newWatcher(
+				watch.Event{Type: watch.Modified, Object: newDebugMode("2", v1.DebugModeStatusWaitForRollback)},
+				watch.Event{Type: watch.Modified, Object: newDebugMode("3", v1.DebugModeStatusCompleted)},
+			), nil
+		}), newDebugMode("", v1.DebugModeStatusSet))
+		sut := NewForControllerRuntimeClient(c, "ecosystem")
+		stored, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+
+		// when
+		result, err := sut.WaitForPhase(testCtx, "debug-mode", v1.DebugModeStatusCompleted, v1.DebugModeStatusFailed)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusCompleted, result.Status.Phase)
+		assert.Equal(t, "3", result.ResourceVersion)
+		assert.Equal(t, stored.ResourceVersion, watchOptions.ResourceVersion)
+		assert.Equal(t, "metadata.name=debug-mode", watchOptions.FieldSelector)
+	})
+	t.Run("should resume watch after reconnect", func(t *testing.T) {
+		// given
+		var resourceVersions []string
+		c := newControllerRuntimeClient(t, interceptWatch(func(_ ctrlclient.WithWatch, opts metav1.ListOptions) (watch.Interface, error) {
+			resourceVersions = append(resourceVersions, opts.ResourceVersion)
+			if len(resourceVersions) == 1 {
+				watcher := newWatcher(watch.Event{Type: watch.Modified, Object: newDebugMode("2", v1.DebugModeStatusWaitForRollback)})
+				watcher.Stop()
+				return watcher, nil
+			}
+			return newWatcher(watch.Event{Type: watch.Modified, Object: newDebugMode("3", v1.DebugModeStatusFailed)}), nil
+		}), newDebugMode("", v1.DebugModeStatusSet))
+		sut := NewForControllerRuntimeClient(c, "ecosystem")
+		stored, err := sut.Get(testCtx, "debug-mode", metav1.GetOptions{})
+		require.NoError(t, err)
+
+		// when
+		result, err := sut.WaitForPhase(testCtx, "debug-mode", v1.DebugModeStatusCompleted, v1.DebugModeStatusFailed)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusFailed, result.Status.Phase)
+		assert.Equal(t, []string{stored.ResourceVersion, "2"}, resourceVersions)
+	})
+	t.Run("should start over with latest version if resource version expired", func(t *testing.T) {
+		// given
+		c := newControllerRuntimeClient(t, interceptWatch(func(c ctrlclient.WithWatch, _ metav1.ListOptions) (watch.Interface, error) {
+			// the debug mode completes while the watch is disconnected
+			stored := &v1.DebugMode{}
+			require.NoError(t, c.Get(testCtx, ctrlclient.ObjectKey{Namespace: "ecosystem", Name: "debug-mode"}, stored))
+			stored.Status.Phase = v1.DebugModeStatusCompleted
+			require.NoError(t, c.Status().Update(testCtx, stored))
+			return newWatcher(watch.Event{Type: watch.Error, Object: &apierrors.NewResourceExpired("too old resource version").ErrStatus}), nil
+		}), newDebugMode("", v1.DebugModeStatusSet))
+		sut := NewForControllerRuntimeClient(c, "ecosystem")
+
+		// when
+		result, err := sut.WaitForPhase(testCtx, "debug-mode", v1.DebugModeStatusCompleted)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.DebugModeStatusCompleted, result.Status.Phase)
+	})
+	t.Run("should fail if debug mode is deleted", func(t *testing.T) {
+		// given
+		c := newControllerRuntimeClient(t, interceptWatch(func(ctrlclient.WithWatch, metav1.ListOptions) (watch.Interface, error) {
+			return newWatcher(watch.Event{Type: watch.Deleted, Object: newDebugMode("2", v1.DebugModeStatusSet)}), nil
+		}), newDebugMode("", v1.DebugModeStatusSet))
+		sut := NewForControllerRuntimeClient(c, "ecosystem")
+
+		// when
+		_, err := sut.WaitForPhase(testCtx, "debug-mode", v1.DebugModeStatusCompleted)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrDebugModeNotFound)
+		assert.ErrorContains(t, err, "failed to wait for debugMode debug-mode")
+	})
+	t.Run("should fail if debug mode does not exist", func(t *testing.T) {
+		// given
+		sut := NewForControllerRuntimeClient(newControllerRuntimeClient(t, interceptor.Funcs{}), "ecosystem")
+
+		// when
+		_, err := sut.WaitForPhase(testCtx, "debug-mode", v1.DebugModeStatusCompleted)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrDebugModeNotFound)
+		assert.ErrorContains(t, err, "failed to get debugMode debug-mode")
+	})
+	t.Run("should fail if context is done", func(t *testing.T) {
+		// given
+		c := newControllerRuntimeClient(t, interceptWatch(func(ctrlclient.WithWatch, metav1.ListOptions) (watch.Interface, error) {
+			return newWatcher(), nil
+		}), newDebugMode("", v1.DebugModeStatusSet))
+		sut := NewForControllerRuntimeClient(c, "ecosystem")
+		ctx, cancel := context.WithTimeout(testCtx, 50*time.Millisecond)
+		defer cancel()
+
+		// when
+		_, err := sut.WaitForPhase(ctx, "debug-mode", v1.DebugModeStatusCompleted)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func Test_helperClient_WaitForCondition(t *testing.T) {
+	t.Run("should watch until condition has status", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		c := newControllerRuntimeClient(t, interceptWatch(func(ctrlclient.WithWatch, metav1.ListOptions) (watch.Interface, error) {
+			notReady := debugMode.DeepCopy()
+			notReady.ResourceVersion = "2"
+			notReady.Status.Conditions = []metav1.Condition{{Type: v1.ConditionReady, Status: metav1.ConditionFalse}}
+			ready := debugMode.DeepCopy()
+			ready.ResourceVersion = "3"
+			ready.Status.Conditions = []metav1.Condition{{Type: v1.ConditionReady, Status: metav1.ConditionTrue}}
+
+			watcher := watch.NewFakeWithChanSize(2, false)
+			watcher.Modify(notReady)
+			watcher.Modify(ready)
+			return watcher, nil
+		}), debugMode)
+		sut := NewForControllerRuntimeClient(c, "ecosystem")
+
+		// when
+		result, err := sut.WaitForCondition(testCtx, "debug-mode", v1.ConditionReady, metav1.ConditionTrue)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "3", result.ResourceVersion)
+	})
+}
+
+func Test_helperClient_WaitFor(t *testing.T) {
+	debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusSet}}
+	newWatcher := func() *watch.FakeWatcher {
+		waiting := debugMode.DeepCopy()
+		waiting.ResourceVersion = "2"
+		waiting.Status.Phase = v1.DebugModeStatusWaitForRollback
+		completed := debugMode.DeepCopy()
+		completed.ResourceVersion = "3"
+		completed.Status.Phase = v1.DebugModeStatusCompleted
+
+		watcher := watch.NewFakeWithChanSize(2, false)
+		watcher.Modify(waiting)
+		watcher.Modify(completed)
+		return watcher
+	}
+
+	t.Run("should call done with current version and every change", func(t *testing.T) {
+		// given
+		c := newControllerRuntimeClient(t, interceptWatch(func(ctrlclient.WithWatch, metav1.ListOptions) (watch.Interface, error) {
+			return newWatcher(), nil
+		}), debugMode.DeepCopy())
+		sut := NewForControllerRuntimeClient(c, "ecosystem")
+		var phases []v1.StatusPhase
+
+		// when
+		result, err := sut.WaitFor(testCtx, "debug-mode", func(debugMode *v1.DebugMode) (bool, error) {
+			phases = append(phases, debugMode.Status.Phase)
+			return debugMode.Status.Phase.IsTerminal(), nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "3", result.ResourceVersion)
+		assert.Equal(t, []v1.StatusPhase{v1.DebugModeStatusSet, v1.DebugModeStatusWaitForRollback, v1.DebugModeStatusCompleted}, phases)
+	})
+	t.Run("should return error of done unchanged", func(t *testing.T) {
+		// given
+		c := newControllerRuntimeClient(t, interceptWatch(func(ctrlclient.WithWatch, metav1.ListOptions) (watch.Interface, error) {
+			return newWatcher(), nil
+		}), debugMode.DeepCopy())
+		sut := NewForControllerRuntimeClient(c, "ecosystem")
+
+		// when
+		result, err := sut.WaitFor(testCtx, "debug-mode", func(debugMode *v1.DebugMode) (bool, error) {
+			if debugMode.Status.Phase == v1.DebugModeStatusWaitForRollback {
+				return false, assert.AnError
+			}
+			return false, nil
+		})
+
+		// then
+		assert.Same(t, assert.AnError, err)
+		assert.Nil(t, result)
+	})
+}
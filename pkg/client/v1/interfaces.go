@@ -8,20 +8,49 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	applyv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/applyconfigurations/api/v1"
 )
 
 type DebugModeV1Interface interface {
 	DebugMode(namespace string) DebugModeInterface
 }
 
-type DebugModeInterface interface {
+// DebugModeResourceInterface contains the plain API operations for debugModes.
+// The helper functions of the DebugModeInterface are built on top of them.
+type DebugModeResourceInterface interface {
 	// Create takes the representation of a debugMode and creates it.  Returns the server's representation of the debugMode, and an error, if there is any.
 	Create(ctx context.Context, debugMode *v1.DebugMode, opts metav1.CreateOptions) (result *v1.DebugMode, err error)
 	// Update takes the representation of a debugMode and updates it. Returns the server's representation of the debugMode, and an error, if there is any.
 	Update(ctx context.Context, debugMode *v1.DebugMode, opts metav1.UpdateOptions) (result *v1.DebugMode, err error)
 	// UpdateStatus was generated because the type contains a Status member.
 	UpdateStatus(ctx context.Context, debugMode *v1.DebugMode, opts metav1.UpdateOptions) (result *v1.DebugMode, err error)
-	// UpdateStatusDebugModeSet sets the status of the debugMode to "SetDebugMode".
+	// Delete takes name of the debugMode and deletes it. Returns an error if one occurs.
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	// DeleteCollection deletes a collection of debugModes. Returns an error if one occurs.
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	// Get takes name of the debugMode, and returns the corresponding debugMode object, and an error if there is any.
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (result *v1.DebugMode, err error)
+	// List takes label and field selectors, and returns the list of debugModes that match those selectors.
+	// If opts.Limit is set, only one chunk is returned and opts.Continue can be used to request the next one.
+	List(ctx context.Context, opts metav1.ListOptions) (result *v1.DebugModeList, err error)
+	// Watch returns a watch.Interface that watches the requested debugModes.
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	// Patch applies the patch and returns the patched debugMode.
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.DebugMode, err error)
+}
+
+// DebugModeInterface contains the plain API operations and helper functions for debugModes.
+// The UpdateStatus* helpers return a *v1.IllegalPhaseTransitionError if the current phase of the debugMode
+// cannot transition to the requested phase. With WithEventRecorder, they record an event for each phase transition.
+// The phase and condition helpers send JSON merge patches to the status subresource instead of fetching and replacing
+// the whole status. The patches carry the resourceVersion of the given debugMode, so they are retried on the latest
+// version if the debugMode changed meanwhile. A debugMode without resourceVersion is fetched before it is patched.
+// Errors of all operations can be checked with errors.Is against the sentinel errors of this package, e.g.
+// ErrDebugModeNotFound, while errors.As still finds the underlying API error.
+type DebugModeInterface interface {
+	DebugModeResourceInterface
+
+	// UpdateStatusDebugModeSet sets the status of the debugMode to "SetDebugMode" and records the resolved deactivation time.
 	UpdateStatusDebugModeSet(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error)
 	// UpdateStatusWaitForRollback sets the status of the debugMode to "WaitForRollback".
 	UpdateStatusWaitForRollback(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error)
@@ -31,18 +60,55 @@ type DebugModeInterface interface {
 	UpdateStatusCompleted(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error)
 	// UpdateStatusFailed sets the status of the debugMode to "Failed".
 	UpdateStatusFailed(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error)
-	// Delete takes name of the debugMode and deletes it. Returns an error if one occurs.
-	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
-	// Get takes name of the debugMode, and returns the corresponding debugMode object, and an error if there is any.
-	Get(ctx context.Context, name string, opts metav1.GetOptions) (result *v1.DebugMode, err error)
-	// Watch returns a watch.Interface that watches the requested debugModes.
-	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
-	// Patch applies the patch and returns the patched debugMode.
-	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.DebugMode, err error)
+	// Apply takes the given apply declarative configuration, applies it with server-side apply and returns the applied debugMode.
+	// opts.FieldManager is required and identifies the owner of the applied fields.
+	Apply(ctx context.Context, debugMode *applyv1.DebugModeApplyConfiguration, opts metav1.ApplyOptions) (result *v1.DebugMode, err error)
+	// ApplyStatus works like Apply but applies the status of the given configuration to the status subresource.
+	ApplyStatus(ctx context.Context, debugMode *applyv1.DebugModeApplyConfiguration, opts metav1.ApplyOptions) (result *v1.DebugMode, err error)
+	// GetSingleton returns the debugMode named v1.SingletonName.
+	GetSingleton(ctx context.Context, opts metav1.GetOptions) (*v1.DebugMode, error)
+	// EnsureSingleton creates the debugMode named v1.SingletonName with the given spec or replaces the spec of the
+	// existing one. On conflicts, the spec is set on the latest version of the debugMode and the update is retried.
+	EnsureSingleton(ctx context.Context, spec v1.DebugModeSpec) (*v1.DebugMode, error)
+	// UpdateWithRetry applies modify to the latest version of the debugMode with the given name and updates it.
+	// On conflicts and retryable errors, modify is reapplied to the latest version and the update is retried with the
+	// backoff of the client. An error returned by modify aborts the update and is returned unchanged.
+	UpdateWithRetry(ctx context.Context, name string, modify func(*v1.DebugMode) error) (*v1.DebugMode, error)
+	// DeleteSingleton deletes the debugMode named v1.SingletonName.
+	DeleteSingleton(ctx context.Context, opts metav1.DeleteOptions) error
+	// ListAll works like List but transparently requests all chunks and returns them in a single list.
+	// opts.Limit is used as the chunk size.
+	ListAll(ctx context.Context, opts metav1.ListOptions) (result *v1.DebugModeList, err error)
 	// AddFinalizer adds the given finalizer to the debugMode.
+	// On conflicts, the finalizer is added to the latest version of the debugMode and the update is retried.
 	AddFinalizer(ctx context.Context, debugMode *v1.DebugMode, finalizer string) (*v1.DebugMode, error)
-	// RemoveFinalizer removes the given finalizer to the debugMode.
+	// RemoveFinalizer removes the given finalizer from the debugMode.
+	// On conflicts, the finalizer is removed from the latest version of the debugMode and the update is retried.
 	RemoveFinalizer(ctx context.Context, debugMode *v1.DebugMode, finalizer string) (*v1.DebugMode, error)
 	// AddOrUpdateLogLevelsSet sets the condition for the debugMode.
+	// On conflicts, the condition is set on the latest version of the debugMode and the update is retried.
 	AddOrUpdateLogLevelsSet(ctx context.Context, debugMode *v1.DebugMode, set bool, msg string, reason string) (*v1.DebugMode, error)
+	// SetCondition adds the condition to the debugMode or updates the existing condition of the same type.
+	// The ObservedGeneration is set to the generation of the given debugMode unless it is already set.
+	// On conflicts, the condition is set on the latest version of the debugMode and the update is retried.
+	SetCondition(ctx context.Context, debugMode *v1.DebugMode, condition metav1.Condition) (*v1.DebugMode, error)
+	// RemoveCondition removes the condition of the given type from the debugMode.
+	// On conflicts, the condition is removed from the latest version of the debugMode and the update is retried.
+	RemoveCondition(ctx context.Context, debugMode *v1.DebugMode, conditionType string) (*v1.DebugMode, error)
+	// AppendError adds the entry to the status errors of the latest version of the debugMode and retries on conflicts.
+	// A missing timestamp is set to now and a missing phase to the current phase. Only the latest v1.MaxErrorEntries are kept.
+	AppendError(ctx context.Context, debugMode *v1.DebugMode, entry v1.ErrorEntry) (*v1.DebugMode, error)
+	// UpdateTargetStatus adds or updates the status of a single target on the latest version of the debugMode
+	// and retries on conflicts. The status of other targets is kept.
+	UpdateTargetStatus(ctx context.Context, debugMode *v1.DebugMode, targetStatus v1.TargetStatus) (*v1.DebugMode, error)
+	// WaitForPhase blocks until the debugMode reaches one of the given phases and returns it.
+	// The debugMode is watched from its current resource version; reconnects and expired resource versions are
+	// handled transparently. A NotFound error is returned if the debugMode does not exist or is deleted while waiting.
+	// Use a context with timeout to limit the wait.
+	WaitForPhase(ctx context.Context, name string, phases ...v1.StatusPhase) (*v1.DebugMode, error)
+	// WaitForCondition works like WaitForPhase but blocks until the condition of the given type has the given status.
+	WaitForCondition(ctx context.Context, name string, conditionType string, status metav1.ConditionStatus) (*v1.DebugMode, error)
+	// WaitFor works like WaitForPhase but calls done with the current version and every change of the debugMode until
+	// done reports true or returns an error, which is returned unchanged. It allows to observe each change on the way.
+	WaitFor(ctx context.Context, name string, done func(*v1.DebugMode) (bool, error)) (*v1.DebugMode, error)
 }
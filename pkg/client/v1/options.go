@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+)
+
+// DefaultBackoff is used by the helper functions to retry conflicts and retryable errors unless WithBackoff is given.
+// It matches the conflict retries of the cloudogu retry-lib. The helpers stop retrying as soon as their context is done.
+var DefaultBackoff = wait.Backoff{
+	Duration: 1500 * time.Millisecond,
+	Factor:   1.5,
+	Jitter:   0,
+	Steps:    9999,
+	Cap:      30 * time.Second,
+}
+
+// IsTransientError reports timeouts, throttling (429) and unavailable API servers (503) as retryable.
+// Pass it to WithRetryableErrorClassifier to retry these errors besides conflicts; combine it with a bounded backoff
+// or a context deadline, as an unavailable API server is otherwise retried as long as conflicts are.
+func IsTransientError(err error) bool {
+	return apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err)
+}
+
+// Observer is notified by the helper functions about conflicts and retries, e.g. to export them as metrics.
+// Implementations must be safe for concurrent use.
+type Observer interface {
+	// ObserveConflict is called for each write of a helper function that is rejected with a conflict.
+	ObserveConflict()
+	// ObserveRetry is called before each repeated attempt of a helper function.
+	ObserveRetry()
+}
+
+type noopObserver struct{}
+
+func (noopObserver) ObserveConflict() {}
+
+func (noopObserver) ObserveRetry() {}
+
+// Option configures the helper functions of a DebugModeInterface.
+type Option func(*helperClient)
+
+// WithBackoff sets the backoff policy used by the helper functions to retry conflicts and retryable errors.
+// backoff.Steps limits the number of attempts.
+func WithBackoff(backoff wait.Backoff) Option {
+	return func(client *helperClient) {
+		client.backoff = backoff
+	}
+}
+
+// WithRetryableErrorClassifier sets the function that decides which errors besides conflicts are retried by the
+// helper functions, e.g. IsTransientError. Conflicts are always retried, as the helpers resolve them by fetching the
+// latest version. By default, no other errors are retried, so the helpers fail fast.
+func WithRetryableErrorClassifier(isRetryable func(error) bool) Option {
+	return func(client *helperClient) {
+		client.isRetryable = isRetryable
+	}
+}
+
+// WithObserver sets the observer that is notified about conflicts and retries of the helper functions.
+func WithObserver(observer Observer) Option {
+	return func(client *helperClient) {
+		client.observer = observer
+	}
+}
+
+// WithTracerProvider sets the OpenTelemetry tracer provider used to trace the operations of the client.
+// Each operation creates a span with the name of the debugMode; the helper functions additionally record the phase
+// transition, the number of retries and conflicts, and the API calls they make as child spans.
+// By default, a no-op provider is used and no spans are recorded.
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(client *helperClient) {
+		client.tracerProvider = tracerProvider
+	}
+}
+
+// WithEventRecorder lets the UpdateStatus* helpers record a Kubernetes event on the debugMode for each phase
+// transition, see EventReason for the reasons. The scheme of the recorder must contain the types of this library,
+// see v1.AddToScheme. By default, no events are recorded.
+func WithEventRecorder(eventRecorder record.EventRecorder) Option {
+	return func(client *helperClient) {
+		client.eventRecorder = eventRecorder
+	}
+}
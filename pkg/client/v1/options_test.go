@@ -0,0 +1,242 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+var testBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+func TestIsTransientError(t *testing.T) {
+	groupResource := schema.GroupResource{Group: "k8s.cloudogu.com", Resource: "debugmodes"}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "server timeout", err: apierrors.NewServerTimeout(groupResource, "patch", 1), want: true},
+		{name: "timeout", err: apierrors.NewTimeoutError("timeout", 1), want: true},
+		{name: "too many requests", err: apierrors.NewTooManyRequests("slow down", 1), want: true},
+		{name: "service unavailable", err: apierrors.NewServiceUnavailable("unavailable"), want: true},
+		{name: "wrapped service unavailable", err: errors.Join(assert.AnError, apierrors.NewServiceUnavailable("unavailable")), want: true},
+		{name: "conflict", err: apierrors.NewConflict(groupResource, "debug-mode", assert.AnError), want: false},
+		{name: "internal error", err: apierrors.NewInternalError(assert.AnError), want: false},
+		{name: "other error", err: assert.AnError, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTransientError(tt.err))
+		})
+	}
+}
+
+// newStatusCodeServer answers the requests in order with the given status codes and an empty debugMode for 200.
+func newStatusCodeServer(t *testing.T, requests *int, codes ...int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		require.Less(t, *requests, len(codes), "unexpected request")
+		code := codes[*requests]
+		*requests++
+
+		if code != http.StatusOK {
+			writer.WriteHeader(code)
+			return
+		}
+
+		result, err := json.Marshal(v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode"}})
+		require.NoError(t, err)
+		writer.Header().Add("content-type", "application/json")
+		_, err = writer.Write(result)
+		require.NoError(t, err)
+	}))
+}
+
+func TestWithBackoff(t *testing.T) {
+	debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "test", ResourceVersion: "1"}}
+
+	t.Run("should retry transient error with latest version", func(t *testing.T) {
+		// given
+		requests := 0
+		server := newStatusCodeServer(t, &requests, http.StatusServiceUnavailable, http.StatusOK, http.StatusOK)
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff), WithRetryableErrorClassifier(IsTransientError))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").UpdateStatusDebugModeSet(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 3, requests, "should patch, get and patch again")
+	})
+	t.Run("should stop after the configured steps", func(t *testing.T) {
+		// given
+		requests := 0
+		server := newStatusCodeServer(t, &requests, http.StatusTooManyRequests, http.StatusTooManyRequests)
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(wait.Backoff{Duration: time.Millisecond, Steps: 2}), WithRetryableErrorClassifier(IsTransientError))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").AddFinalizer(testCtx, debugMode.DeepCopy(), "my-finalizer")
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsTooManyRequests(err))
+		assert.Equal(t, 2, requests)
+	})
+	t.Run("should not retry transient errors by default", func(t *testing.T) {
+		// given
+		requests := 0
+		server := newStatusCodeServer(t, &requests, http.StatusServiceUnavailable)
+		client, err := NewForConfig(&rest.Config{Host: server.URL})
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").UpdateStatusDebugModeSet(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsServiceUnavailable(err))
+		assert.Equal(t, 1, requests)
+	})
+	t.Run("should stop retrying when context is done", func(t *testing.T) {
+		// given
+		requests := 0
+		server := newStatusCodeServer(t, &requests, http.StatusConflict, http.StatusConflict)
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(wait.Backoff{Duration: time.Hour, Steps: 10}))
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(testCtx, 50*time.Millisecond)
+		defer cancel()
+
+		// when
+		_, err = client.DebugMode("test").AddFinalizer(ctx, debugMode.DeepCopy(), "my-finalizer")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.True(t, apierrors.IsConflict(err))
+		assert.Equal(t, 1, requests)
+	})
+	t.Run("should not retry other errors", func(t *testing.T) {
+		// given
+		requests := 0
+		server := newStatusCodeServer(t, &requests, http.StatusInternalServerError)
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").UpdateStatusDebugModeSet(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, 1, requests)
+	})
+}
+
+func TestWithRetryableErrorClassifier(t *testing.T) {
+	debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "test", ResourceVersion: "1"}}
+
+	t.Run("should retry errors of the classifier", func(t *testing.T) {
+		// given
+		requests := 0
+		server := newStatusCodeServer(t, &requests, http.StatusInternalServerError, http.StatusOK, http.StatusOK)
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff), WithRetryableErrorClassifier(apierrors.IsInternalError))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").SetCondition(testCtx, debugMode.DeepCopy(), metav1.Condition{Type: v1.ConditionReady, Status: metav1.ConditionTrue})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 3, requests)
+	})
+	t.Run("should always retry conflicts", func(t *testing.T) {
+		// given
+		requests := 0
+		server := newStatusCodeServer(t, &requests, http.StatusConflict, http.StatusOK, http.StatusOK)
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff), WithRetryableErrorClassifier(func(error) bool { return false }))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").UpdateStatusDebugModeSet(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 3, requests)
+	})
+	t.Run("should not retry transient errors if classifier refuses", func(t *testing.T) {
+		// given
+		requests := 0
+		server := newStatusCodeServer(t, &requests, http.StatusServiceUnavailable)
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff), WithRetryableErrorClassifier(func(error) bool { return false }))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").UpdateStatusDebugModeSet(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsServiceUnavailable(err))
+		assert.Equal(t, 1, requests)
+	})
+}
+
+type countingObserver struct {
+	conflicts int
+	retries   int
+}
+
+func (o *countingObserver) ObserveConflict() { o.conflicts++ }
+
+func (o *countingObserver) ObserveRetry() { o.retries++ }
+
+func TestWithObserver(t *testing.T) {
+	debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "test", ResourceVersion: "1"}}
+
+	t.Run("should observe conflicts and retries", func(t *testing.T) {
+		// given
+		requests := 0
+		server := newStatusCodeServer(t, &requests, http.StatusConflict, http.StatusOK, http.StatusServiceUnavailable, http.StatusOK, http.StatusOK)
+		observer := &countingObserver{}
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff), WithObserver(observer), WithRetryableErrorClassifier(IsTransientError))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").UpdateStatusDebugModeSet(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, observer.conflicts)
+		assert.Equal(t, 2, observer.retries)
+	})
+	t.Run("should not observe successful first attempt", func(t *testing.T) {
+		// given
+		requests := 0
+		server := newStatusCodeServer(t, &requests, http.StatusOK)
+		observer := &countingObserver{}
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithObserver(observer))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").UpdateStatusDebugModeSet(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, observer.conflicts)
+		assert.Zero(t, observer.retries)
+	})
+}
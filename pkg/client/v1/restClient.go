@@ -2,9 +2,6 @@ package v1
 
 import (
 	"context"
-	"fmt"
-	"github.com/cloudogu/retry-lib/retry"
-	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/scheme"
 	"time"
@@ -13,9 +10,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// debugModeClient implements the plain API operations for debugModes using REST calls.
 type debugModeClient struct {
 	client rest.Interface
 	ns     string
@@ -60,74 +57,26 @@ func (client *debugModeClient) UpdateStatus(ctx context.Context, debugMode *v1.D
 	return
 }
 
-func (client *debugModeClient) UpdateStatusCompleted(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error) {
-	debugMode, err := client.updateStatusWithRetry(ctx, debugMode, v1.DebugModeStatusCompleted)
-	if err != nil {
-		return nil, err
-	}
-
-	return debugMode, nil
-}
-
-func (client *debugModeClient) UpdateStatusDebugModeSet(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error) {
-	debugMode, err := client.updateStatusWithRetry(ctx, debugMode, v1.DebugModeStatusSet)
-	if err != nil {
-		return nil, err
-	}
-
-	return debugMode, nil
-}
-
-func (client *debugModeClient) UpdateStatusRollback(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error) {
-	debugMode, err := client.updateStatusWithRetry(ctx, debugMode, v1.DebugModeStatusRollback)
-	if err != nil {
-		return nil, err
-	}
-
-	return debugMode, nil
-}
-
-func (client *debugModeClient) UpdateStatusWaitForRollback(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error) {
-	debugMode, err := client.updateStatusWithRetry(ctx, debugMode, v1.DebugModeStatusWaitForRollback)
-	if err != nil {
-		return nil, err
-	}
-
-	return debugMode, nil
+func (client *debugModeClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return client.client.Delete().
+		Namespace(client.ns).
+		Resource("debugmodes").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
 }
 
-func (client *debugModeClient) UpdateStatusFailed(ctx context.Context, debugMode *v1.DebugMode) (*v1.DebugMode, error) {
-	debugMode, err := client.updateStatusWithRetry(ctx, debugMode, v1.DebugModeStatusFailed)
-	if err != nil {
-		return nil, err
+func (client *debugModeClient) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
 	}
-
-	return debugMode, nil
-}
-
-func (client *debugModeClient) updateStatusWithRetry(ctx context.Context, debugMode *v1.DebugMode, targetStatus v1.StatusPhase) (*v1.DebugMode, error) {
-	var resultDebugMode *v1.DebugMode
-	err := retry.OnConflict(func() error {
-		updatedDebugMode, err := client.Get(ctx, debugMode.GetName(), metav1.GetOptions{})
-		if err != nil {
-			return err
-		}
-
-		// do not overwrite the whole status, so we do not lose other values from the Status object
-		// esp. a potentially set requeue time
-		updatedDebugMode.Status.Phase = targetStatus
-		resultDebugMode, err = client.UpdateStatus(ctx, updatedDebugMode, metav1.UpdateOptions{})
-		return err
-	})
-
-	return resultDebugMode, err
-}
-
-func (client *debugModeClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
 	return client.client.Delete().
 		Namespace(client.ns).
 		Resource("debugmodes").
-		Name(name).
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
 		Body(&opts).
 		Do(ctx).
 		Error()
@@ -145,6 +94,22 @@ func (client *debugModeClient) Get(ctx context.Context, name string, opts metav1
 	return
 }
 
+func (client *debugModeClient) List(ctx context.Context, opts metav1.ListOptions) (result *v1.DebugModeList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.DebugModeList{}
+	err = client.client.Get().
+		Namespace(client.ns).
+		Resource("debugmodes").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 func (client *debugModeClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
 	var timeout time.Duration
 	if opts.TimeoutSeconds != nil {
@@ -172,55 +137,3 @@ func (client *debugModeClient) Patch(ctx context.Context, name string, pt types.
 		Into(result)
 	return
 }
-
-func (client *debugModeClient) AddFinalizer(ctx context.Context, debugMode *v1.DebugMode, finalizer string) (*v1.DebugMode, error) {
-	controllerutil.AddFinalizer(debugMode, finalizer)
-	result, err := client.Update(ctx, debugMode, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to add finalizer %s to debugMode: %w", finalizer, err)
-	}
-
-	return result, nil
-
-}
-
-func (client *debugModeClient) RemoveFinalizer(ctx context.Context, debugMode *v1.DebugMode, finalizer string) (*v1.DebugMode, error) {
-	controllerutil.RemoveFinalizer(debugMode, finalizer)
-	result, err := client.Update(ctx, debugMode, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to remove finalizer %s from debugMode: %w", finalizer, err)
-	}
-
-	return result, err
-}
-
-func (client *debugModeClient) AddOrUpdateLogLevelsSet(ctx context.Context, debugMode *v1.DebugMode, set bool, msg string, reason string) (*v1.DebugMode, error) {
-	conditionStatus := metav1.ConditionFalse
-	if set == true {
-		conditionStatus = metav1.ConditionTrue
-	}
-
-	if reason == "" {
-		reason = "Initialized"
-	}
-
-	if msg == "" {
-		msg = "Condition set to initialized"
-	}
-
-	newCondition := metav1.Condition{
-		Type:               v1.ConditionLogLevelSet,
-		Status:             conditionStatus,
-		Reason:             reason,
-		Message:            msg,
-		LastTransitionTime: metav1.Now(),
-	}
-
-	_ = meta.SetStatusCondition(&debugMode.Status.Conditions, newCondition)
-	result, err := client.UpdateStatus(ctx, debugMode, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to add or update condition %s to debugMode: %w", newCondition.Type, err)
-	}
-
-	return result, nil
-}
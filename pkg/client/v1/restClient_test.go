@@ -253,6 +253,222 @@ func Test_DebugModeClient_Watch(t *testing.T) {
 	})
 }
 
+func Test_DebugModeClient_List(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodGet, request.Method)
+			assert.Equal(t, "/apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes", request.URL.Path)
+			assert.Equal(t, http.NoBody, request.Body)
+			assert.Equal(t, "continue=abc&labelSelector=test&limit=1", request.URL.RawQuery)
+
+			debugModeList := &v1.DebugModeList{
+				ListMeta: metav1.ListMeta{Continue: "def"},
+				Items:    []v1.DebugMode{{ObjectMeta: metav1.ObjectMeta{Name: "testDebugMode", Namespace: "test"}}},
+			}
+			debugModeListBytes, err := json.Marshal(debugModeList)
+			require.NoError(t, err)
+
+			writer.Header().Add("content-type", "application/json")
+			_, err = writer.Write(debugModeListBytes)
+			require.NoError(t, err)
+		}))
+
+		config := rest.Config{
+			Host: server.URL,
+		}
+		client, err := NewForConfig(&config)
+		require.NoError(t, err)
+		sClient := client.DebugMode("test")
+
+		// when
+		result, err := sClient.List(testCtx, metav1.ListOptions{LabelSelector: "test", Limit: 1, Continue: "abc"})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, "testDebugMode", result.Items[0].Name)
+		assert.Equal(t, "def", result.Continue)
+	})
+	t.Run("should list across all namespaces", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodGet, request.Method)
+			assert.Equal(t, "/apis/k8s.cloudogu.com/v1/debugmodes", request.URL.Path)
+
+			writer.Header().Add("content-type", "application/json")
+			_, err := writer.Write([]byte(`{"items":[]}`))
+			require.NoError(t, err)
+		}))
+
+		config := rest.Config{
+			Host: server.URL,
+		}
+		client, err := NewForConfig(&config)
+		require.NoError(t, err)
+		sClient := client.DebugMode("")
+
+		// when
+		result, err := sClient.List(testCtx, metav1.ListOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, result.Items)
+	})
+}
+
+func Test_DebugModeClient_ListAll(t *testing.T) {
+	t.Run("should request all chunks", func(t *testing.T) {
+		// given
+		chunks := map[string]*v1.DebugModeList{
+			"": {
+				ListMeta: metav1.ListMeta{ResourceVersion: "42", Continue: "second"},
+				Items:    []v1.DebugMode{{ObjectMeta: metav1.ObjectMeta{Name: "first"}}},
+			},
+			"second": {
+				ListMeta: metav1.ListMeta{ResourceVersion: "42", Continue: "third"},
+				Items:    []v1.DebugMode{{ObjectMeta: metav1.ObjectMeta{Name: "second"}}},
+			},
+			"third": {
+				ListMeta: metav1.ListMeta{ResourceVersion: "42"},
+				Items:    []v1.DebugMode{{ObjectMeta: metav1.ObjectMeta{Name: "third"}}},
+			},
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodGet, request.Method)
+			assert.Equal(t, "/apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes", request.URL.Path)
+			assert.Equal(t, "1", request.URL.Query().Get("limit"))
+
+			chunk, ok := chunks[request.URL.Query().Get("continue")]
+			require.True(t, ok)
+			chunkBytes, err := json.Marshal(chunk)
+			require.NoError(t, err)
+
+			writer.Header().Add("content-type", "application/json")
+			_, err = writer.Write(chunkBytes)
+			require.NoError(t, err)
+		}))
+
+		config := rest.Config{
+			Host: server.URL,
+		}
+		client, err := NewForConfig(&config)
+		require.NoError(t, err)
+		sClient := client.DebugMode("test")
+
+		// when
+		result, err := sClient.ListAll(testCtx, metav1.ListOptions{Limit: 1})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result.Items, 3)
+		assert.Equal(t, "first", result.Items[0].Name)
+		assert.Equal(t, "second", result.Items[1].Name)
+		assert.Equal(t, "third", result.Items[2].Name)
+		assert.Equal(t, "42", result.ResourceVersion)
+		assert.Empty(t, result.Continue)
+	})
+	t.Run("should only send resource version with first chunk", func(t *testing.T) {
+		// given
+		chunks := map[string]*v1.DebugModeList{
+			"": {
+				ListMeta: metav1.ListMeta{ResourceVersion: "42", Continue: "second"},
+				Items:    []v1.DebugMode{{ObjectMeta: metav1.ObjectMeta{Name: "first"}}},
+			},
+			"second": {
+				ListMeta: metav1.ListMeta{ResourceVersion: "42"},
+				Items:    []v1.DebugMode{{ObjectMeta: metav1.ObjectMeta{Name: "second"}}},
+			},
+		}
+
+		var queries []string
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			queries = append(queries, request.URL.RawQuery)
+			query := request.URL.Query()
+			if query.Get("continue") != "" && (query.Has("resourceVersion") || query.Has("resourceVersionMatch")) {
+				// like the API server, reject a resource version together with a continue token
+				writer.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			chunk, ok := chunks[query.Get("continue")]
+			require.True(t, ok)
+			chunkBytes, err := json.Marshal(chunk)
+			require.NoError(t, err)
+
+			writer.Header().Add("content-type", "application/json")
+			_, err = writer.Write(chunkBytes)
+			require.NoError(t, err)
+		}))
+
+		config := rest.Config{
+			Host: server.URL,
+		}
+		client, err := NewForConfig(&config)
+		require.NoError(t, err)
+		sClient := client.DebugMode("test")
+
+		// when
+		result, err := sClient.ListAll(testCtx, metav1.ListOptions{Limit: 1, ResourceVersion: "40", ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result.Items, 2)
+		assert.Equal(t, []string{
+			"limit=1&resourceVersion=40&resourceVersionMatch=NotOlderThan",
+			"continue=second&limit=1",
+		}, queries)
+	})
+	t.Run("should fail on list error", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(500)
+		}))
+
+		config := rest.Config{
+			Host: server.URL,
+		}
+		client, err := NewForConfig(&config)
+		require.NoError(t, err)
+		sClient := client.DebugMode("test")
+
+		// when
+		_, err = sClient.ListAll(testCtx, metav1.ListOptions{Limit: 1})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to list debugModes")
+	})
+}
+
+func Test_DebugModeClient_DeleteCollection(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodDelete, request.Method)
+			assert.Equal(t, "/apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes", request.URL.Path)
+			assert.Equal(t, "labelSelector=test", request.URL.RawQuery)
+
+			writer.Header().Add("content-type", "application/json")
+			writer.WriteHeader(200)
+		}))
+
+		config := rest.Config{
+			Host: server.URL,
+		}
+		client, err := NewForConfig(&config)
+		require.NoError(t, err)
+		sClient := client.DebugMode("test")
+
+		// when
+		err = sClient.DeleteCollection(testCtx, metav1.DeleteOptions{}, metav1.ListOptions{LabelSelector: "test"})
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
 func Test_DebugModeClient_UpdateStatusDebugModeSet(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		// given
@@ -298,7 +514,7 @@ func Test_DebugModeClient_UpdateStatusDebugModeSet(t *testing.T) {
 func Test_DebugModeClient_UpdateStatusWaitForRollback(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		// given
-		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}}
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusSet}}
 		mockClient := mockClientForStatusUpdates(t, DebugMode, v1.DebugModeStatusWaitForRollback, false, false)
 		sClient := mockClient.DebugMode("test")
 
@@ -310,7 +526,7 @@ func Test_DebugModeClient_UpdateStatusWaitForRollback(t *testing.T) {
 	})
 	t.Run("success with retry", func(t *testing.T) {
 		// given
-		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}}
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusSet}}
 		mockClient := mockClientForStatusUpdates(t, DebugMode, v1.DebugModeStatusWaitForRollback, true, false)
 		sClient := mockClient.DebugMode("test")
 
@@ -322,7 +538,7 @@ func Test_DebugModeClient_UpdateStatusWaitForRollback(t *testing.T) {
 	})
 	t.Run("fail on get DebugMode", func(t *testing.T) {
 		// given
-		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}}
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusSet}}
 		mockClient := mockClientForStatusUpdates(t, DebugMode, v1.DebugModeStatusWaitForRollback, false, true)
 		sClient := mockClient.DebugMode("test")
 
@@ -338,7 +554,7 @@ func Test_DebugModeClient_UpdateStatusWaitForRollback(t *testing.T) {
 func Test_DebugModeClient_UpdateStatusRollback(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		// given
-		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}}
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusWaitForRollback}}
 		mockClient := mockClientForStatusUpdates(t, DebugMode, v1.DebugModeStatusRollback, false, false)
 		sClient := mockClient.DebugMode("test")
 
@@ -350,7 +566,7 @@ func Test_DebugModeClient_UpdateStatusRollback(t *testing.T) {
 	})
 	t.Run("success with retry", func(t *testing.T) {
 		// given
-		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}}
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusWaitForRollback}}
 		mockClient := mockClientForStatusUpdates(t, DebugMode, v1.DebugModeStatusRollback, true, false)
 		sClient := mockClient.DebugMode("test")
 
@@ -362,7 +578,7 @@ func Test_DebugModeClient_UpdateStatusRollback(t *testing.T) {
 	})
 	t.Run("fail on get DebugMode", func(t *testing.T) {
 		// given
-		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}}
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusWaitForRollback}}
 		mockClient := mockClientForStatusUpdates(t, DebugMode, v1.DebugModeStatusWaitForRollback, false, true)
 		sClient := mockClient.DebugMode("test")
 
@@ -418,7 +634,7 @@ func Test_DebugModeClient_UpdateStatusFailed(t *testing.T) {
 func Test_DebugModeClient_UpdateStatusCompleted(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		// given
-		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}}
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusRollback}}
 		mockClient := mockClientForStatusUpdates(t, DebugMode, v1.DebugModeStatusCompleted, false, false)
 		sClient := mockClient.DebugMode("test")
 
@@ -430,7 +646,7 @@ func Test_DebugModeClient_UpdateStatusCompleted(t *testing.T) {
 	})
 	t.Run("success with retry", func(t *testing.T) {
 		// given
-		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}}
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusRollback}}
 		mockClient := mockClientForStatusUpdates(t, DebugMode, v1.DebugModeStatusCompleted, true, false)
 		sClient := mockClient.DebugMode("test")
 
@@ -442,7 +658,7 @@ func Test_DebugModeClient_UpdateStatusCompleted(t *testing.T) {
 	})
 	t.Run("fail on get DebugMode", func(t *testing.T) {
 		// given
-		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}}
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}, Status: v1.DebugModeStatus{Phase: v1.DebugModeStatusRollback}}
 		mockClient := mockClientForStatusUpdates(t, DebugMode, v1.DebugModeStatusCompleted, false, true)
 		sClient := mockClient.DebugMode("test")
 
@@ -529,6 +745,25 @@ func Test_DebugModeClient_AddFinalizer(t *testing.T) {
 		require.Error(t, err)
 		assert.ErrorContains(t, err, "failed to add finalizer myFinalizer to debugMode:")
 	})
+
+	t.Run("should add finalizer to latest version on conflict", func(t *testing.T) {
+		// given
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test", ResourceVersion: "1"}}
+		latest := DebugMode.DeepCopy()
+		latest.ResourceVersion = "2"
+		latest.Finalizers = []string{"otherFinalizer"}
+		sClient := mockClientForConflict(t, latest, "", func(written *v1.DebugMode) {
+			assert.Equal(t, []string{"otherFinalizer", "myFinalizer"}, written.Finalizers)
+		}).DebugMode("test")
+
+		// when
+		result, err := sClient.AddFinalizer(testCtx, DebugMode, "myFinalizer")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"otherFinalizer", "myFinalizer"}, result.Finalizers)
+		assert.Empty(t, DebugMode.Finalizers, "should not modify the given debugMode")
+	})
 }
 
 func Test_DebugModeClient_RemoveFinalizer(t *testing.T) {
@@ -609,16 +844,35 @@ func Test_DebugModeClient_RemoveFinalizer(t *testing.T) {
 		require.Error(t, err)
 		assert.ErrorContains(t, err, "failed to remove finalizer finalizer2 from debugMode")
 	})
+
+	t.Run("should remove finalizer from latest version on conflict", func(t *testing.T) {
+		// given
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test", ResourceVersion: "1", Finalizers: []string{"finalizer1"}}}
+		latest := DebugMode.DeepCopy()
+		latest.ResourceVersion = "2"
+		latest.Finalizers = []string{"finalizer1", "finalizer2"}
+		sClient := mockClientForConflict(t, latest, "", func(written *v1.DebugMode) {
+			assert.Equal(t, []string{"finalizer2"}, written.Finalizers)
+		}).DebugMode("test")
+
+		// when
+		result, err := sClient.RemoveFinalizer(testCtx, DebugMode, "finalizer1")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"finalizer2"}, result.Finalizers)
+	})
 }
 
 func Test_DebugModeClient_AddOrUpdateLogLevelsSetCondition(t *testing.T) {
 	t.Run("success condition set to false", func(t *testing.T) {
 		// given
-		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}}
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test", ResourceVersion: "1"}}
 
 		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
-			assert.Equal(t, http.MethodPut, request.Method)
+			assert.Equal(t, http.MethodPatch, request.Method)
 			assert.Equal(t, "/apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/myDebugMode/status", request.URL.Path)
+			assert.Equal(t, string(types.MergePatchType), request.Header.Get("Content-Type"))
 
 			bytes, err := io.ReadAll(request.Body)
 			require.NoError(t, err)
@@ -641,27 +895,31 @@ func Test_DebugModeClient_AddOrUpdateLogLevelsSetCondition(t *testing.T) {
 		sClient := client.DebugMode("test")
 
 		// when
-		_, err = sClient.AddOrUpdateLogLevelsSet(testCtx, DebugMode, false, "", "")
+		result, err := sClient.AddOrUpdateLogLevelsSet(testCtx, DebugMode, false, "", "")
 
 		// then
 		require.NoError(t, err)
-		require.Len(t, DebugMode.Status.Conditions, 1)
-		require.Equal(t, metav1.ConditionFalse, meta.FindStatusCondition(DebugMode.Status.Conditions, v1.ConditionLogLevelSet).Status)
+		require.Len(t, result.Status.Conditions, 1)
+		require.Equal(t, metav1.ConditionFalse, meta.FindStatusCondition(result.Status.Conditions, v1.ConditionLogLevelSet).Status)
+		assert.Empty(t, DebugMode.Status.Conditions, "should not modify the given debugMode")
 	})
 
 	t.Run("success condition set to true", func(t *testing.T) {
 		// given
-		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test"}}
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test", ResourceVersion: "1"}}
 
 		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
-			assert.Equal(t, http.MethodPut, request.Method)
+			assert.Equal(t, http.MethodPatch, request.Method)
 			assert.Equal(t, "/apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/myDebugMode/status", request.URL.Path)
+			assert.Equal(t, string(types.MergePatchType), request.Header.Get("Content-Type"))
 
 			bytes, err := io.ReadAll(request.Body)
 			require.NoError(t, err)
 
-			createdDebugMode := &v1.DebugMode{}
-			require.NoError(t, json.Unmarshal(bytes, createdDebugMode))
+			patchedDebugMode := &v1.DebugMode{}
+			require.NoError(t, json.Unmarshal(bytes, patchedDebugMode))
+			require.Len(t, patchedDebugMode.Status.Conditions, 1)
+			assert.Equal(t, metav1.ConditionTrue, meta.FindStatusCondition(patchedDebugMode.Status.Conditions, v1.ConditionLogLevelSet).Status)
 
 			writer.WriteHeader(500)
 			writer.Header().Add("content-type", "application/json")
@@ -682,12 +940,34 @@ func Test_DebugModeClient_AddOrUpdateLogLevelsSetCondition(t *testing.T) {
 
 		// then
 		require.Error(t, err)
-		require.Len(t, DebugMode.Status.Conditions, 1)
-		require.Equal(t, metav1.ConditionTrue, meta.FindStatusCondition(DebugMode.Status.Conditions, v1.ConditionLogLevelSet).Status)
+		assert.Empty(t, DebugMode.Status.Conditions, "should not modify the given debugMode")
+	})
+
+	t.Run("should set condition on latest version on conflict", func(t *testing.T) {
+		// given
+		DebugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "myDebugMode", Namespace: "test", ResourceVersion: "1"}}
+		latest := DebugMode.DeepCopy()
+		latest.ResourceVersion = "2"
+		latest.Status.Phase = v1.DebugModeStatusSet
+		meta.SetStatusCondition(&latest.Status.Conditions, metav1.Condition{Type: v1.ConditionDegraded, Status: metav1.ConditionTrue, Reason: "TargetFailed"})
+		sClient := mockClientForConflict(t, latest, "status", func(written *v1.DebugMode) {
+			assert.Empty(t, written.Status.Phase, "should only patch the conditions")
+			assert.True(t, meta.IsStatusConditionTrue(written.Status.Conditions, v1.ConditionLogLevelSet))
+			assert.True(t, meta.IsStatusConditionTrue(written.Status.Conditions, v1.ConditionDegraded))
+		}).DebugMode("test")
+
+		// when
+		_, err := sClient.AddOrUpdateLogLevelsSet(testCtx, DebugMode, true, "all set", "LevelsApplied")
+
+		// then
+		require.NoError(t, err)
 	})
 }
 
 func mockClientForStatusUpdates(t *testing.T, expectedDebugMode *v1.DebugMode, expectedStatus v1.StatusPhase, withRetry bool, failOnGetDebugMode bool) DebugModeV1Interface {
+	// the debugMode was read from the API server, so the status patches carry its resourceVersion as precondition
+	expectedDebugMode.ResourceVersion = "1"
+	storedResourceVersion := expectedDebugMode.ResourceVersion
 
 	failGetDebugMode := func(writer http.ResponseWriter, request *http.Request) {
 		assert.Equal(t, http.MethodGet, request.Method)
@@ -700,7 +980,9 @@ func mockClientForStatusUpdates(t *testing.T, expectedDebugMode *v1.DebugMode, e
 		assert.Equal(t, http.MethodGet, request.Method)
 		assert.Equal(t, fmt.Sprintf("/apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/%s", expectedDebugMode.Name), request.URL.Path)
 
-		DebugModeJson, err := json.Marshal(expectedDebugMode)
+		storedDebugMode := expectedDebugMode.DeepCopy()
+		storedDebugMode.ResourceVersion = storedResourceVersion
+		DebugModeJson, err := json.Marshal(storedDebugMode)
 		require.NoError(t, err)
 
 		writer.Header().Add("content-type", "application/json")
@@ -708,27 +990,43 @@ func mockClientForStatusUpdates(t *testing.T, expectedDebugMode *v1.DebugMode, e
 		require.NoError(t, err)
 	}
 
-	assertUpdateStatusRequest := func(writer http.ResponseWriter, request *http.Request) {
-		assert.Equal(t, http.MethodPut, request.Method)
+	assertPatchStatusRequest := func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, http.MethodPatch, request.Method)
 		assert.Equal(t, fmt.Sprintf("/apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/%s/status", expectedDebugMode.Name), request.URL.Path)
+		assert.Equal(t, string(types.MergePatchType), request.Header.Get("Content-Type"))
 
 		bytes, err := io.ReadAll(request.Body)
 		require.NoError(t, err)
 
-		createdDebugMode := &v1.DebugMode{}
-		require.NoError(t, json.Unmarshal(bytes, createdDebugMode))
-		assert.Equal(t, expectedDebugMode.Name, createdDebugMode.Name)
-		assert.Equal(t, expectedStatus, createdDebugMode.Status.Phase)
+		patch := &v1.DebugMode{}
+		require.NoError(t, json.Unmarshal(bytes, patch))
+		assert.Empty(t, patch.Name, "should only patch the status")
+		assert.Equal(t, storedResourceVersion, patch.ResourceVersion)
+		assert.Equal(t, expectedStatus, patch.Status.Phase)
+
+		patchedDebugMode := expectedDebugMode.DeepCopy()
+		patchedDebugMode.Status.Phase = patch.Status.Phase
+		patchedDebugModeJson, err := json.Marshal(patchedDebugMode)
+		require.NoError(t, err)
 
 		writer.Header().Add("content-type", "application/json")
-		_, err = writer.Write(bytes)
+		_, err = writer.Write(patchedDebugModeJson)
 		require.NoError(t, err)
 	}
 
-	conflictUpdateStatusRequest := func(writer http.ResponseWriter, request *http.Request) {
-		assert.Equal(t, http.MethodPut, request.Method)
+	conflictPatchStatusRequest := func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, http.MethodPatch, request.Method)
 		assert.Equal(t, fmt.Sprintf("/apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/%s/status", expectedDebugMode.Name), request.URL.Path)
 
+		bytes, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+
+		// the stored debugMode was changed by another client, so the precondition of the patch fails
+		patch := &v1.DebugMode{}
+		require.NoError(t, json.Unmarshal(bytes, patch))
+		assert.Equal(t, storedResourceVersion, patch.ResourceVersion)
+		storedResourceVersion = "2"
+
 		writer.WriteHeader(409)
 	}
 
@@ -736,19 +1034,18 @@ func mockClientForStatusUpdates(t *testing.T, expectedDebugMode *v1.DebugMode, e
 
 	if failOnGetDebugMode {
 		requestAssertions = []func(writer http.ResponseWriter, request *http.Request){
+			conflictPatchStatusRequest,
 			failGetDebugMode,
 		}
 	} else if withRetry {
 		requestAssertions = []func(writer http.ResponseWriter, request *http.Request){
+			conflictPatchStatusRequest,
 			assertGetDebugModeRequest,
-			conflictUpdateStatusRequest,
-			assertGetDebugModeRequest,
-			assertUpdateStatusRequest,
+			assertPatchStatusRequest,
 		}
 	} else {
 		requestAssertions = []func(writer http.ResponseWriter, request *http.Request){
-			assertGetDebugModeRequest,
-			assertUpdateStatusRequest,
+			assertPatchStatusRequest,
 		}
 	}
 
@@ -762,7 +1059,79 @@ func mockClientForStatusUpdates(t *testing.T, expectedDebugMode *v1.DebugMode, e
 	config := rest.Config{
 		Host: server.URL,
 	}
-	client, err := NewForConfig(&config)
+	client, err := NewForConfig(&config, WithBackoff(testBackoff))
+	require.NoError(t, err)
+	return client
+}
+
+// mockClientForConflict answers the first write of the given subresource with a conflict, as if another client
+// changed the debugMode in the meantime. It then serves the latest debugMode and passes the retried write to
+// assertRetriedWrite.
+func mockClientForConflict(t *testing.T, latest *v1.DebugMode, subresource string, assertRetriedWrite func(written *v1.DebugMode)) DebugModeV1Interface {
+	path := fmt.Sprintf("/apis/k8s.cloudogu.com/v1/namespaces/test/debugmodes/%s", latest.Name)
+	writePath, writeMethod := path, http.MethodPut
+	if subresource != "" {
+		writePath, writeMethod = path+"/"+subresource, http.MethodPatch
+	}
+
+	readWrite := func(request *http.Request) ([]byte, *v1.DebugMode) {
+		assert.Equal(t, writeMethod, request.Method)
+		assert.Equal(t, writePath, request.URL.Path)
+
+		bytes, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+		written := &v1.DebugMode{}
+		require.NoError(t, json.Unmarshal(bytes, written))
+		return bytes, written
+	}
+
+	conflictWriteRequest := func(writer http.ResponseWriter, request *http.Request) {
+		_, written := readWrite(request)
+		assert.Equal(t, "1", written.ResourceVersion)
+
+		writer.WriteHeader(409)
+	}
+
+	assertGetDebugModeRequest := func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, http.MethodGet, request.Method)
+		assert.Equal(t, path, request.URL.Path)
+
+		latestJson, err := json.Marshal(latest)
+		require.NoError(t, err)
+
+		writer.Header().Add("content-type", "application/json")
+		_, err = writer.Write(latestJson)
+		require.NoError(t, err)
+	}
+
+	assertRetriedWriteRequest := func(writer http.ResponseWriter, request *http.Request) {
+		bytes, written := readWrite(request)
+		assert.Equal(t, latest.ResourceVersion, written.ResourceVersion)
+		assertRetriedWrite(written)
+
+		writer.Header().Add("content-type", "application/json")
+		_, err := writer.Write(bytes)
+		require.NoError(t, err)
+	}
+
+	requestAssertions := []func(writer http.ResponseWriter, request *http.Request){
+		conflictWriteRequest,
+		assertGetDebugModeRequest,
+		assertRetriedWriteRequest,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		require.NotEmpty(t, requestAssertions, "unexpected request")
+		assertRequestFunc := requestAssertions[0]
+		requestAssertions = requestAssertions[1:]
+
+		assertRequestFunc(writer, request)
+	}))
+	t.Cleanup(func() {
+		server.Close()
+		assert.Empty(t, requestAssertions, "missing requests")
+	})
+
+	client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff))
 	require.NoError(t, err)
 	return client
 }
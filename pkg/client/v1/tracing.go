@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the tracer of the client, as recommended by OpenTelemetry the import path of the package.
+const tracerName = "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+
+const (
+	attributeMethod        = attribute.Key("debugmode.method")
+	attributeName          = attribute.Key("debugmode.name")
+	attributePhaseFrom     = attribute.Key("debugmode.phase.from")
+	attributePhaseTo       = attribute.Key("debugmode.phase.to")
+	attributeRetries       = attribute.Key("debugmode.retries")
+	attributeConflicts     = attribute.Key("debugmode.conflicts")
+	attributeConflictState = attribute.Key("debugmode.conflict.outcome")
+	attributeAttempt       = attribute.Key("debugmode.attempt")
+	attributeFinalizer     = attribute.Key("debugmode.finalizer")
+	attributeCondition     = attribute.Key("debugmode.condition.type")
+)
+
+const (
+	// conflictResolved marks spans whose conflicts were resolved by a retry.
+	conflictResolved = "resolved"
+	// conflictUnresolved marks spans that failed with a conflict after all retries.
+	conflictUnresolved = "unresolved"
+)
+
+// startSpan starts a client span for the given method and debugMode. The returned context must be passed to the
+// API operations, so their spans become children of this span.
+func (client *helperClient) startSpan(ctx context.Context, method string, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	attributes = append([]attribute.KeyValue{attributeMethod.String(method), attributeName.String(name)}, attributes...)
+	return client.tracer.Start(ctx, "DebugMode."+method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+}
+
+// endSpan records the error, if any, and ends the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+func newRecordingTracerProvider() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	result := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		result[kv.Key] = kv.Value
+	}
+
+	return result
+}
+
+func TestWithTracerProvider(t *testing.T) {
+	debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "test", ResourceVersion: "1"}}
+
+	t.Run("should trace phase transition with resolved conflict", func(t *testing.T) {
+		// given
+		requests := 0
+		server := newStatusCodeServer(t, &requests, http.StatusConflict, http.StatusOK, http.StatusOK)
+		tracerProvider, recorder := newRecordingTracerProvider()
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithBackoff(testBackoff), WithTracerProvider(tracerProvider))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").UpdateStatusDebugModeSet(testCtx, debugMode.DeepCopy())
+
+		// then
+		require.NoError(t, err)
+		spans := recorder.Ended()
+		require.Len(t, spans, 4)
+		assert.Equal(t, "DebugMode.Patch", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, "DebugMode.Get", spans[1].Name())
+		assert.Equal(t, "DebugMode.Patch", spans[2].Name())
+		assert.Equal(t, codes.Unset, spans[2].Status().Code)
+
+		helperSpan := spans[3]
+		assert.Equal(t, "DebugMode.UpdateStatusDebugModeSet", helperSpan.Name())
+		assert.Equal(t, trace.SpanKindClient, helperSpan.SpanKind())
+		assert.Equal(t, codes.Unset, helperSpan.Status().Code)
+		for _, child := range spans[:3] {
+			assert.Equal(t, helperSpan.SpanContext().SpanID(), child.Parent().SpanID())
+		}
+
+		attributes := spanAttributes(helperSpan)
+		assert.Equal(t, "UpdateStatusDebugModeSet", attributes[attributeMethod].AsString())
+		assert.Equal(t, "debug-mode", attributes[attributeName].AsString())
+		assert.Equal(t, "", attributes[attributePhaseFrom].AsString())
+		assert.Equal(t, string(v1.DebugModeStatusSet), attributes[attributePhaseTo].AsString())
+		assert.Equal(t, int64(1), attributes[attributeRetries].AsInt64())
+		assert.Equal(t, int64(1), attributes[attributeConflicts].AsInt64())
+		assert.Equal(t, conflictResolved, attributes[attributeConflictState].AsString())
+		require.Len(t, helperSpan.Events(), 1)
+		assert.Equal(t, "conflict", helperSpan.Events()[0].Name)
+	})
+	t.Run("should record error of failed helper", func(t *testing.T) {
+		// given
+		requests := 0
+		server := newStatusCodeServer(t, &requests, http.StatusInternalServerError)
+		tracerProvider, recorder := newRecordingTracerProvider()
+		client, err := NewForConfig(&rest.Config{Host: server.URL}, WithTracerProvider(tracerProvider))
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").AddFinalizer(testCtx, debugMode.DeepCopy(), "my-finalizer")
+
+		// then
+		require.Error(t, err)
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "DebugMode.Update", spans[0].Name())
+		helperSpan := spans[1]
+		assert.Equal(t, "DebugMode.AddFinalizer", helperSpan.Name())
+		assert.Equal(t, codes.Error, helperSpan.Status().Code)
+		assert.Equal(t, err.Error(), helperSpan.Status().Description)
+		attributes := spanAttributes(helperSpan)
+		assert.Equal(t, "my-finalizer", attributes[attributeFinalizer].AsString())
+		assert.Equal(t, int64(0), attributes[attributeRetries].AsInt64())
+		assert.Equal(t, int64(0), attributes[attributeConflicts].AsInt64())
+		_, hasOutcome := attributes[attributeConflictState]
+		assert.False(t, hasOutcome)
+	})
+	t.Run("should not record spans by default", func(t *testing.T) {
+		// given
+		requests := 0
+		server := newStatusCodeServer(t, &requests, http.StatusOK)
+		client, err := NewForConfig(&rest.Config{Host: server.URL})
+		require.NoError(t, err)
+
+		// when
+		_, err = client.DebugMode("test").Get(testCtx, "debug-mode", metav1.GetOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.IsType(t, noop.Tracer{}, client.DebugMode("test").(*helperClient).tracer)
+	})
+}
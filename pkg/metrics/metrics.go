@@ -0,0 +1,205 @@
+// Package metrics exports the state of debug modes as Prometheus metrics.
+//
+// The Recorder caches the debug modes it receives from an informer and computes the gauges on each scrape, so the
+// remaining time until the deactivation is always up to date. Activations and failed rollbacks are counted from
+// the phase transitions seen by the informer. As clientv1.Observer, the Recorder additionally counts the conflicts
+// and retries of the client helpers:
+//
+//	recorder := metrics.NewRecorder()
+//	prometheus.MustRegister(recorder)
+//	_, err := recorder.AddToInformer(factory.DebugModeV1().DebugModes().Informer())
+//	debugModes := clientSet.DebugModeV1().DebugMode(namespace) // created with clientv1.WithObserver(recorder)
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	clientv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+)
+
+var (
+	activeDesc = prometheus.NewDesc("debug_mode_active",
+		"Whether a debug mode is active, i.e. exists in a non-terminal phase (1) or not (0).", nil, nil)
+	phaseDesc = prometheus.NewDesc("debug_mode_phase",
+		"The current phase of the debug mode; the series of the current phase is 1, the others are 0.",
+		[]string{"namespace", "name", "phase"}, nil)
+	secondsUntilDeactivationDesc = prometheus.NewDesc("debug_mode_seconds_until_deactivation",
+		"Seconds until the deactivation time of the active debug mode is reached, 0 if it has passed.",
+		[]string{"namespace", "name"}, nil)
+)
+
+// phases are the phases reported by debug_mode_phase. The empty phase of a new debug mode is not reported.
+var phases = []v1.StatusPhase{
+	v1.DebugModeStatusSet,
+	v1.DebugModeStatusWaitForRollback,
+	v1.DebugModeStatusRollback,
+	v1.DebugModeStatusCompleted,
+	v1.DebugModeStatusFailed,
+}
+
+// Recorder collects the metrics of debug modes. Register it at a prometheus.Registerer, e.g. the registry of a
+// controller-runtime manager, and feed it with AddToInformer and clientv1.WithObserver.
+type Recorder struct {
+	mu         sync.RWMutex
+	debugModes map[types.NamespacedName]*v1.DebugMode
+	now        func() time.Time
+
+	activations     prometheus.Counter
+	failedRollbacks prometheus.Counter
+	conflicts       prometheus.Counter
+	retries         prometheus.Counter
+}
+
+var (
+	_ prometheus.Collector = &Recorder{}
+	_ clientv1.Observer    = &Recorder{}
+)
+
+// NewRecorder creates a recorder without any debug modes.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		debugModes: map[types.NamespacedName]*v1.DebugMode{},
+		now:        time.Now,
+		activations: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "debug_mode_activations_total",
+			Help: "Number of debug modes that transitioned to the phase " + string(v1.DebugModeStatusSet) + " after creation.",
+		}),
+		failedRollbacks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "debug_mode_failed_rollbacks_total",
+			Help: "Number of debug modes that failed while rolling back the original log levels.",
+		}),
+		conflicts: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "debug_mode_update_conflicts_total",
+			Help: "Number of writes of the client helpers that were rejected with a conflict.",
+		}),
+		retries: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "debug_mode_update_retries_total",
+			Help: "Number of repeated attempts of the client helpers after conflicts and retryable errors.",
+		}),
+	}
+}
+
+// AddToInformer registers the recorder as event handler at the given debug mode informer.
+func (r *Recorder) AddToInformer(informer cache.SharedInformer) (cache.ResourceEventHandlerRegistration, error) {
+	return informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			if debugMode, ok := obj.(*v1.DebugMode); ok {
+				r.set(debugMode)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			oldDebugMode, oldOk := oldObj.(*v1.DebugMode)
+			newDebugMode, newOk := newObj.(*v1.DebugMode)
+			if !oldOk || !newOk {
+				return
+			}
+
+			r.countTransition(oldDebugMode.Status.Phase, newDebugMode.Status.Phase)
+			r.set(newDebugMode)
+		},
+		DeleteFunc: func(obj any) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if debugMode, ok := obj.(*v1.DebugMode); ok {
+				r.delete(debugMode)
+			}
+		},
+	})
+}
+
+func (r *Recorder) set(debugMode *v1.DebugMode) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.debugModes[types.NamespacedName{Namespace: debugMode.Namespace, Name: debugMode.Name}] = debugMode
+}
+
+func (r *Recorder) delete(debugMode *v1.DebugMode) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.debugModes, types.NamespacedName{Namespace: debugMode.Namespace, Name: debugMode.Name})
+}
+
+func (r *Recorder) countTransition(from, to v1.StatusPhase) {
+	if from == to {
+		return
+	}
+
+	// only the first transition counts, extending a debug mode moves it from WaitForRollback back to Set
+	if from == "" && to == v1.DebugModeStatusSet {
+		r.activations.Inc()
+	}
+
+	if (from == v1.DebugModeStatusWaitForRollback || from == v1.DebugModeStatusRollback) && to == v1.DebugModeStatusFailed {
+		r.failedRollbacks.Inc()
+	}
+}
+
+// ObserveConflict counts a write of the client helpers that was rejected with a conflict.
+func (r *Recorder) ObserveConflict() {
+	r.conflicts.Inc()
+}
+
+// ObserveRetry counts a repeated attempt of the client helpers.
+func (r *Recorder) ObserveRetry() {
+	r.retries.Inc()
+}
+
+// Describe implements prometheus.Collector.
+func (r *Recorder) Describe(ch chan<- *prometheus.Desc) {
+	ch <- activeDesc
+	ch <- phaseDesc
+	ch <- secondsUntilDeactivationDesc
+	r.activations.Describe(ch)
+	r.failedRollbacks.Describe(ch)
+	r.conflicts.Describe(ch)
+	r.retries.Describe(ch)
+}
+
+// Collect implements prometheus.Collector. The gauges are computed from the cached debug modes.
+func (r *Recorder) Collect(ch chan<- prometheus.Metric) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := r.now()
+	active := 0.0
+	for key, debugMode := range r.debugModes {
+		for _, phase := range phases {
+			ch <- prometheus.MustNewConstMetric(phaseDesc, prometheus.GaugeValue,
+				boolToFloat(debugMode.Status.Phase == phase), key.Namespace, key.Name, string(phase))
+		}
+
+		if debugMode.Status.Phase.IsTerminal() {
+			continue
+		}
+
+		active = 1
+		deactivationTime := debugMode.EffectiveDeactivationTime()
+		if !deactivationTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(secondsUntilDeactivationDesc, prometheus.GaugeValue,
+				debugMode.RemainingDuration(now).Seconds(), key.Namespace, key.Name)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(activeDesc, prometheus.GaugeValue, active)
+	r.activations.Collect(ch)
+	r.failedRollbacks.Collect(ch)
+	r.conflicts.Collect(ch)
+	r.retries.Collect(ch)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
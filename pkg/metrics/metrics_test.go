@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/fake"
+	"github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/informers"
+	clientv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+)
+
+var (
+	testCtx = context.Background()
+	testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+)
+
+// startRecorder feeds a new recorder from an informer over the debug modes of the given client set.
+func startRecorder(t *testing.T, clientSet *fake.Clientset) *Recorder {
+	t.Helper()
+
+	recorder := NewRecorder()
+	recorder.now = func() time.Time { return testNow }
+
+	factory := informers.NewSharedInformerFactoryWithOptions(clientSet, time.Minute, informers.WithNamespace("ecosystem"))
+	_, err := recorder.AddToInformer(factory.DebugModeV1().DebugModes().Informer())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(func() {
+		cancel()
+		factory.Shutdown()
+	})
+	factory.Start(ctx.Done())
+	for _, ok := range factory.WaitForCacheSync(ctx.Done()) {
+		require.True(t, ok)
+	}
+
+	return recorder
+}
+
+func TestRecorder_Collect(t *testing.T) {
+	t.Run("should report active debug mode", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"},
+			Spec:       v1.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(testNow.Add(90 * time.Second))},
+			Status:     v1.DebugModeStatus{Phase: v1.DebugModeStatusSet},
+		}
+		sut := startRecorder(t, fake.NewSimpleClientset(debugMode))
+
+		// when
+		err := testutil.CollectAndCompare(sut, strings.NewReader(`
+# HELP debug_mode_active Whether a debug mode is active, i.e. exists in a non-terminal phase (1) or not (0).
+# TYPE debug_mode_active gauge
+debug_mode_active 1
+# HELP debug_mode_phase The current phase of the debug mode; the series of the current phase is 1, the others are 0.
+# TYPE debug_mode_phase gauge
+debug_mode_phase{name="debug-mode",namespace="ecosystem",phase="Completed"} 0
+debug_mode_phase{name="debug-mode",namespace="ecosystem",phase="Failed"} 0
+debug_mode_phase{name="debug-mode",namespace="ecosystem",phase="Rollback"} 0
+debug_mode_phase{name="debug-mode",namespace="ecosystem",phase="SetDebugMode"} 1
+debug_mode_phase{name="debug-mode",namespace="ecosystem",phase="WaitForRollback"} 0
+# HELP debug_mode_seconds_until_deactivation Seconds until the deactivation time of the active debug mode is reached, 0 if it has passed.
+# TYPE debug_mode_seconds_until_deactivation gauge
+debug_mode_seconds_until_deactivation{name="debug-mode",namespace="ecosystem"} 90
+`), "debug_mode_active", "debug_mode_phase", "debug_mode_seconds_until_deactivation")
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should report inactive without debug mode", func(t *testing.T) {
+		// given
+		sut := startRecorder(t, fake.NewSimpleClientset())
+
+		// when
+		err := testutil.CollectAndCompare(sut, strings.NewReader(`
+# HELP debug_mode_active Whether a debug mode is active, i.e. exists in a non-terminal phase (1) or not (0).
+# TYPE debug_mode_active gauge
+debug_mode_active 0
+`), "debug_mode_active", "debug_mode_phase", "debug_mode_seconds_until_deactivation")
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should report completed debug mode as inactive", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"},
+			Spec:       v1.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(testNow.Add(-time.Minute))},
+			Status:     v1.DebugModeStatus{Phase: v1.DebugModeStatusCompleted},
+		}
+		sut := startRecorder(t, fake.NewSimpleClientset(debugMode))
+
+		// when
+		err := testutil.CollectAndCompare(sut, strings.NewReader(`
+# HELP debug_mode_active Whether a debug mode is active, i.e. exists in a non-terminal phase (1) or not (0).
+# TYPE debug_mode_active gauge
+debug_mode_active 0
+`), "debug_mode_active", "debug_mode_seconds_until_deactivation")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 5, testutil.CollectAndCount(sut, "debug_mode_phase"))
+	})
+}
+
+func TestRecorder_AddToInformer(t *testing.T) {
+	t.Run("should count activations and failed rollbacks and forget deleted debug modes", func(t *testing.T) {
+		// given
+		debugMode := &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}}
+		clientSet := fake.NewSimpleClientset(debugMode)
+		sut := startRecorder(t, clientSet)
+		debugModes := clientSet.DebugModeV1().DebugMode("ecosystem")
+
+		// when
+		set, err := debugModes.UpdateStatusDebugModeSet(testCtx, debugMode)
+		require.NoError(t, err)
+		waitForRollback, err := debugModes.UpdateStatusWaitForRollback(testCtx, set)
+		require.NoError(t, err)
+		extended, err := debugModes.UpdateStatusDebugModeSet(testCtx, waitForRollback)
+		require.NoError(t, err)
+		waitForRollback, err = debugModes.UpdateStatusWaitForRollback(testCtx, extended)
+		require.NoError(t, err)
+		_, err = debugModes.UpdateStatusFailed(testCtx, waitForRollback)
+		require.NoError(t, err)
+
+		// then
+		assert.Eventually(t, func() bool {
+			return testutil.ToFloat64(sut.failedRollbacks) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, 1.0, testutil.ToFloat64(sut.activations))
+
+		// when
+		err = debugModes.Delete(testCtx, "debug-mode", metav1.DeleteOptions{})
+		require.NoError(t, err)
+
+		// then
+		assert.Eventually(t, func() bool {
+			return testutil.CollectAndCount(sut, "debug_mode_phase") == 0
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestRecorder_Observer(t *testing.T) {
+	t.Run("should count conflicts and retries", func(t *testing.T) {
+		// given
+		var sut clientv1.Observer = NewRecorder()
+
+		// when
+		sut.ObserveConflict()
+		sut.ObserveRetry()
+		sut.ObserveRetry()
+
+		// then
+		assert.Equal(t, 1.0, testutil.ToFloat64(sut.(*Recorder).conflicts))
+		assert.Equal(t, 2.0, testutil.ToFloat64(sut.(*Recorder).retries))
+	})
+}
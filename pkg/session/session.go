@@ -0,0 +1,191 @@
+// Package session provides a high-level API to activate, extend and deactivate the debug mode.
+// It operates on the singleton debug mode and handles the cases of an already existing resource,
+// so UIs and scripts behave the same.
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	clientv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+)
+
+var (
+	// ErrAlreadyActive is returned if the debug mode should be activated while another session is still running.
+	// It is the same error as clientv1.ErrAlreadyActive.
+	ErrAlreadyActive = clientv1.ErrAlreadyActive
+	// ErrNotActive is returned if the debug mode should be extended while no session is running.
+	ErrNotActive = errors.New("debug mode is not active")
+	// ErrTerminating is returned if the debug mode should be activated while the previous session is still being deleted.
+	ErrTerminating = errors.New("previous debug mode is still terminating")
+)
+
+// Session activates, extends and deactivates the debug mode.
+type Session struct {
+	debugModes clientv1.DebugModeInterface
+	now        func() time.Time
+}
+
+// NewSession creates a session for the debug mode managed by the given client.
+func NewSession(debugModes clientv1.DebugModeInterface) *Session {
+	return &Session{debugModes: debugModes, now: time.Now}
+}
+
+// Activate starts a debug mode with the given log level for the given duration.
+// A completed or failed debug mode of a previous session is replaced.
+// ErrAlreadyActive is returned if another session is still running, even if it was activated concurrently, and
+// ErrTerminating if the previous debug mode is still being deleted, which usually resolves itself after the rollback
+// of the original log levels.
+func (s *Session) Activate(ctx context.Context, logLevel string, duration time.Duration) (*v1.DebugMode, error) {
+	existing, err := s.debugModes.GetSingleton(ctx, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get debug mode: %w", err)
+	}
+
+	if err == nil {
+		err = s.removeFinishedSession(ctx, existing)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	debugMode := &v1.DebugMode{
+		ObjectMeta: metav1.ObjectMeta{Name: v1.SingletonName},
+		Spec: v1.DebugModeSpec{
+			TargetLogLevel: logLevel,
+			Duration:       &metav1.Duration{Duration: duration},
+		},
+	}
+	created, err := s.debugModes.Create(ctx, debugMode, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil, s.existingSessionError(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create debug mode: %w", err)
+	}
+
+	return created, nil
+}
+
+// existingSessionError tells why the debug mode could not be created although no running session was found before:
+// either the previous debug mode waits for its finalizers or another client has activated a session in the meantime.
+func (s *Session) existingSessionError(ctx context.Context) error {
+	existing, err := s.debugModes.GetSingleton(ctx, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		// the previous debug mode has been removed in the meantime, so activating again succeeds
+		return ErrTerminating
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get debug mode: %w", err)
+	}
+
+	if existing.DeletionTimestamp != nil {
+		return ErrTerminating
+	}
+
+	return fmt.Errorf("%w: debug mode is in phase %q", ErrAlreadyActive, existing.Status.Phase)
+}
+
+func (s *Session) removeFinishedSession(ctx context.Context, existing *v1.DebugMode) error {
+	if existing.DeletionTimestamp != nil {
+		return ErrTerminating
+	}
+
+	if !existing.Status.Phase.IsTerminal() {
+		return fmt.Errorf("%w: debug mode is in phase %q", ErrAlreadyActive, existing.Status.Phase)
+	}
+
+	err := s.debugModes.DeleteSingleton(ctx, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &existing.UID}})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete finished debug mode: %w", err)
+	}
+
+	return nil
+}
+
+// Extend prolongs the running debug mode by the given duration. If the deactivation time has already passed but the
+// original log levels are not yet restored, the debug mode is extended from now on.
+// A debug mode activated with a Duration is extended relative to its server-stamped creation time, so the local clock
+// is only used to restart a debug mode whose deactivation time has passed, as signaled by the phase WaitForRollback.
+// An explicit DeactivateTimestamp is an absolute time and is therefore compared with and extended from the local clock.
+// ErrNotActive is returned if no session is running or its rollback has already started.
+// Conflicts and retryable errors are retried with the backoff the client was configured with.
+func (s *Session) Extend(ctx context.Context, extra time.Duration) (*v1.DebugMode, error) {
+	var extendErr error
+	result, err := s.debugModes.UpdateWithRetry(ctx, v1.SingletonName, func(debugMode *v1.DebugMode) error {
+		extendErr = s.extend(debugMode, extra)
+		return extendErr
+	})
+	switch {
+	case extendErr != nil:
+		return nil, extendErr
+	case errors.Is(err, clientv1.ErrDebugModeNotFound):
+		return nil, ErrNotActive
+	case err != nil:
+		return nil, fmt.Errorf("failed to update debug mode: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *Session) extend(debugMode *v1.DebugMode, extra time.Duration) error {
+	if !isExtendable(debugMode) {
+		return fmt.Errorf("%w: debug mode is in phase %q", ErrNotActive, debugMode.Status.Phase)
+	}
+
+	if debugMode.Spec.DeactivateTimestamp.IsZero() && debugMode.Spec.Duration != nil {
+		debugMode.Spec.Duration = &metav1.Duration{Duration: s.extendedDuration(debugMode, extra)}
+		return nil
+	}
+
+	deactivationTime := debugMode.EffectiveDeactivationTime()
+	if deactivationTime.IsZero() {
+		return fmt.Errorf("failed to extend debug mode: deactivation time of debug mode is unknown")
+	}
+
+	now := s.now()
+	if deactivationTime.Before(&metav1.Time{Time: now}) {
+		deactivationTime = metav1.NewTime(now)
+	}
+	debugMode.Spec.DeactivateTimestamp = metav1.NewTime(deactivationTime.Add(extra))
+
+	return nil
+}
+
+// extendedDuration prolongs the Duration of the debugMode by extra. While the debug mode is running, the Duration is
+// simply increased, which keeps the deactivation independent of the local clock. After the deactivation time has
+// passed, the debug mode is restarted from the local now, but never shortened.
+func (s *Session) extendedDuration(debugMode *v1.DebugMode, extra time.Duration) time.Duration {
+	duration := debugMode.Spec.Duration.Duration
+	if debugMode.Status.Phase == v1.DebugModeStatusWaitForRollback {
+		duration = max(duration, s.now().Sub(debugMode.CreationTimestamp.Time))
+	}
+
+	return duration + extra
+}
+
+func isExtendable(debugMode *v1.DebugMode) bool {
+	if debugMode.DeletionTimestamp != nil {
+		return false
+	}
+
+	phase := debugMode.Status.Phase
+	return !phase.IsTerminal() && phase != v1.DebugModeStatusRollback
+}
+
+// Deactivate ends the debug mode by deleting it. The operator restores the original log levels before it removes its
+// finalizer. Deactivating a debug mode that does not exist is not treated as error.
+func (s *Session) Deactivate(ctx context.Context) error {
+	err := s.debugModes.DeleteSingleton(ctx, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete debug mode: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,370 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clienttesting "k8s.io/client-go/testing"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/fake"
+	clientv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+)
+
+var testCtx = context.Background()
+
+var testNow = time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestSession(objects ...runtime.Object) (*Session, *fake.Clientset) {
+	clientSet := fake.NewSimpleClientset(objects...)
+	session := NewSession(clientSet.DebugModeV1().DebugMode("ecosystem"))
+	session.now = func() time.Time { return testNow }
+	return session, clientSet
+}
+
+func newDebugMode(phase v1.StatusPhase) *v1.DebugMode {
+	return &v1.DebugMode{
+		ObjectMeta: metav1.ObjectMeta{Name: v1.SingletonName, Namespace: "ecosystem", UID: "old-uid", CreationTimestamp: metav1.NewTime(testNow.Add(-time.Hour))},
+		Spec:       v1.DebugModeSpec{TargetLogLevel: v1.LogLevelDebug, DeactivateTimestamp: metav1.NewTime(testNow.Add(time.Hour))},
+		Status:     v1.DebugModeStatus{Phase: phase},
+	}
+}
+
+func TestSession_Activate(t *testing.T) {
+	t.Run("should create debug mode", func(t *testing.T) {
+		// given
+		sut, _ := newTestSession()
+
+		// when
+		result, err := sut.Activate(testCtx, v1.LogLevelDebug, 2*time.Hour)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.SingletonName, result.Name)
+		assert.Equal(t, v1.LogLevelDebug, result.Spec.TargetLogLevel)
+		assert.Equal(t, &metav1.Duration{Duration: 2 * time.Hour}, result.Spec.Duration)
+	})
+	t.Run("should replace finished debug mode", func(t *testing.T) {
+		for _, phase := range []v1.StatusPhase{v1.DebugModeStatusCompleted, v1.DebugModeStatusFailed} {
+			t.Run(string(phase), func(t *testing.T) {
+				// given
+				sut, _ := newTestSession(newDebugMode(phase))
+
+				// when
+				result, err := sut.Activate(testCtx, v1.LogLevelInfo, time.Hour)
+
+				// then
+				require.NoError(t, err)
+				assert.Equal(t, v1.LogLevelInfo, result.Spec.TargetLogLevel)
+				assert.Empty(t, result.Status.Phase)
+			})
+		}
+	})
+	t.Run("should fail if debug mode is already active", func(t *testing.T) {
+		// given
+		sut, _ := newTestSession(newDebugMode(v1.DebugModeStatusSet))
+
+		// when
+		_, err := sut.Activate(testCtx, v1.LogLevelDebug, time.Hour)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrAlreadyActive)
+		assert.ErrorContains(t, err, "debug mode is in phase \"SetDebugMode\"")
+	})
+	t.Run("should fail if previous debug mode is terminating", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeStatusRollback)
+		deletionTimestamp := metav1.NewTime(testNow)
+		debugMode.DeletionTimestamp = &deletionTimestamp
+		debugMode.Finalizers = []string{"debugmode-finalizer"}
+		sut, _ := newTestSession(debugMode)
+
+		// when
+		_, err := sut.Activate(testCtx, v1.LogLevelDebug, time.Hour)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrTerminating)
+	})
+	t.Run("should fail if deleted debug mode still exists", func(t *testing.T) {
+		// given
+		sut, clientSet := newTestSession(newDebugMode(v1.DebugModeStatusCompleted))
+		clientSet.PrependReactor("delete", "debugmodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			// the operator still holds a finalizer, so the debug mode is only marked for deletion
+			terminating := newDebugMode(v1.DebugModeStatusCompleted)
+			deletionTimestamp := metav1.NewTime(testNow)
+			terminating.DeletionTimestamp = &deletionTimestamp
+			terminating.Finalizers = []string{"debugmode-finalizer"}
+			require.NoError(t, clientSet.Tracker().Update(v1.GroupVersion.WithResource("debugmodes"), terminating, "ecosystem"))
+			return true, nil, nil
+		})
+
+		// when
+		_, err := sut.Activate(testCtx, v1.LogLevelDebug, time.Hour)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrTerminating)
+	})
+	t.Run("should fail if debug mode is activated concurrently", func(t *testing.T) {
+		// given
+		sut, clientSet := newTestSession()
+		clientSet.PrependReactor("create", "debugmodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			// another client creates the debug mode between the get and the create of the session
+			require.NoError(t, clientSet.Tracker().Add(newDebugMode(v1.DebugModeStatusSet)))
+			return true, nil, apierrors.NewAlreadyExists(v1.GroupVersion.WithResource("debugmodes").GroupResource(), v1.SingletonName)
+		})
+
+		// when
+		_, err := sut.Activate(testCtx, v1.LogLevelDebug, time.Hour)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrAlreadyActive)
+		assert.NotErrorIs(t, err, ErrTerminating)
+		assert.ErrorContains(t, err, "debug mode is in phase \"SetDebugMode\"")
+	})
+	t.Run("should fail if debug mode cannot be read after create conflict", func(t *testing.T) {
+		// given
+		sut, clientSet := newTestSession()
+		gets := 0
+		clientSet.PrependReactor("get", "debugmodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			gets++
+			if gets == 1 {
+				return true, nil, apierrors.NewNotFound(v1.GroupVersion.WithResource("debugmodes").GroupResource(), v1.SingletonName)
+			}
+			return true, nil, assert.AnError
+		})
+		clientSet.PrependReactor("create", "debugmodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewAlreadyExists(v1.GroupVersion.WithResource("debugmodes").GroupResource(), v1.SingletonName)
+		})
+
+		// when
+		_, err := sut.Activate(testCtx, v1.LogLevelDebug, time.Hour)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get debug mode")
+	})
+	t.Run("should fail on get error", func(t *testing.T) {
+		// given
+		sut, clientSet := newTestSession()
+		clientSet.PrependReactor("get", "debugmodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, assert.AnError
+		})
+
+		// when
+		_, err := sut.Activate(testCtx, v1.LogLevelDebug, time.Hour)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get debug mode")
+	})
+}
+
+func TestSession_Extend(t *testing.T) {
+	t.Run("should extend deactivate timestamp", func(t *testing.T) {
+		// given
+		sut, _ := newTestSession(newDebugMode(v1.DebugModeStatusSet))
+
+		// when
+		result, err := sut.Extend(testCtx, 30*time.Minute)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, testNow.Add(90*time.Minute).Equal(result.Spec.DeactivateTimestamp.Time))
+	})
+	t.Run("should extend duration", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeStatusSet)
+		debugMode.Spec.DeactivateTimestamp = metav1.Time{}
+		debugMode.Spec.Duration = &metav1.Duration{Duration: 2 * time.Hour}
+		sut, _ := newTestSession(debugMode)
+
+		// when
+		result, err := sut.Extend(testCtx, 30*time.Minute)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &metav1.Duration{Duration: 150 * time.Minute}, result.Spec.Duration)
+		assert.True(t, result.Spec.DeactivateTimestamp.IsZero())
+	})
+	t.Run("should extend duration independent of local clock", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeStatusSet)
+		debugMode.Spec.DeactivateTimestamp = metav1.Time{}
+		debugMode.Spec.Duration = &metav1.Duration{Duration: 2 * time.Hour}
+		sut, _ := newTestSession(debugMode)
+		// the local clock is ahead of the cluster, so the deactivation time seems to have passed
+		sut.now = func() time.Time { return testNow.Add(3 * time.Hour) }
+
+		// when
+		result, err := sut.Extend(testCtx, 30*time.Minute)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &metav1.Duration{Duration: 150 * time.Minute}, result.Spec.Duration)
+	})
+	t.Run("should extend duration from now if deactivation time has passed", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeStatusWaitForRollback)
+		debugMode.Spec.DeactivateTimestamp = metav1.Time{}
+		debugMode.Spec.Duration = &metav1.Duration{Duration: 30 * time.Minute}
+		sut, _ := newTestSession(debugMode)
+
+		// when
+		result, err := sut.Extend(testCtx, 30*time.Minute)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &metav1.Duration{Duration: 90 * time.Minute}, result.Spec.Duration)
+	})
+	t.Run("should not shorten passed duration if local clock is behind", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeStatusWaitForRollback)
+		debugMode.Spec.DeactivateTimestamp = metav1.Time{}
+		debugMode.Spec.Duration = &metav1.Duration{Duration: 2 * time.Hour}
+		sut, _ := newTestSession(debugMode)
+
+		// when
+		result, err := sut.Extend(testCtx, 30*time.Minute)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &metav1.Duration{Duration: 150 * time.Minute}, result.Spec.Duration)
+	})
+	t.Run("should extend from now if deactivation time has passed", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeStatusWaitForRollback)
+		debugMode.Spec.DeactivateTimestamp = metav1.NewTime(testNow.Add(-time.Minute))
+		sut, _ := newTestSession(debugMode)
+
+		// when
+		result, err := sut.Extend(testCtx, 30*time.Minute)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, testNow.Add(30*time.Minute).Equal(result.Spec.DeactivateTimestamp.Time))
+	})
+	t.Run("should fail if debug mode does not exist", func(t *testing.T) {
+		// given
+		sut, _ := newTestSession()
+
+		// when
+		_, err := sut.Extend(testCtx, time.Hour)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrNotActive)
+	})
+	t.Run("should fail if rollback has started or finished", func(t *testing.T) {
+		for _, phase := range []v1.StatusPhase{v1.DebugModeStatusRollback, v1.DebugModeStatusCompleted, v1.DebugModeStatusFailed} {
+			t.Run(string(phase), func(t *testing.T) {
+				// given
+				sut, _ := newTestSession(newDebugMode(phase))
+
+				// when
+				_, err := sut.Extend(testCtx, time.Hour)
+
+				// then
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrNotActive)
+			})
+		}
+	})
+	t.Run("should retry with backoff of the client", func(t *testing.T) {
+		// given
+		scheme := runtime.NewScheme()
+		require.NoError(t, v1.AddToScheme(scheme))
+		attempts := 0
+		c := ctrlfake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(newDebugMode(v1.DebugModeStatusSet)).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(ctx context.Context, client ctrlclient.WithWatch, obj ctrlclient.Object, opts ...ctrlclient.UpdateOption) error {
+					attempts++
+					return apierrors.NewConflict(schema.GroupResource{Group: "k8s.cloudogu.com", Resource: "debugmodes"}, v1.SingletonName, assert.AnError)
+				},
+			}).
+			Build()
+		sut := NewSession(clientv1.NewForControllerRuntimeClient(c, "ecosystem", clientv1.WithBackoff(wait.Backoff{Steps: 2, Duration: time.Millisecond})))
+		sut.now = func() time.Time { return testNow }
+
+		// when
+		_, err := sut.Extend(testCtx, 30*time.Minute)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsConflict(err))
+		assert.Equal(t, 2, attempts)
+	})
+	t.Run("should fail on update error", func(t *testing.T) {
+		// given
+		sut, clientSet := newTestSession(newDebugMode(v1.DebugModeStatusSet))
+		clientSet.PrependReactor("update", "debugmodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "k8s.cloudogu.com", Resource: "debugmodes"}, v1.SingletonName, assert.AnError)
+		})
+
+		// when
+		_, err := sut.Extend(testCtx, time.Hour)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsForbidden(err))
+		assert.ErrorContains(t, err, "failed to update debug mode")
+	})
+}
+
+func TestSession_Deactivate(t *testing.T) {
+	t.Run("should delete debug mode", func(t *testing.T) {
+		// given
+		sut, clientSet := newTestSession(newDebugMode(v1.DebugModeStatusSet))
+
+		// when
+		err := sut.Deactivate(testCtx)
+
+		// then
+		require.NoError(t, err)
+		_, err = clientSet.DebugModeV1().DebugMode("ecosystem").GetSingleton(testCtx, metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+	t.Run("should ignore missing debug mode", func(t *testing.T) {
+		// given
+		sut, _ := newTestSession()
+
+		// when
+		err := sut.Deactivate(testCtx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail on delete error", func(t *testing.T) {
+		// given
+		sut, clientSet := newTestSession(newDebugMode(v1.DebugModeStatusSet))
+		clientSet.PrependReactor("delete", "debugmodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, assert.AnError
+		})
+
+		// when
+		err := sut.Deactivate(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,155 @@
+// Package state contains the snapshot of the original log levels that a debug mode changed.
+// The snapshot is stored in a ConfigMap owned by the debug mode, so the log levels can be restored on rollback.
+//
+// ConfigMap keys must not contain slashes, so the targets are stored as "<kind>_<name>", e.g. "dogu_cas", instead of
+// the "<kind>/<name>" of v1.LogLevelTarget.Key. TargetKey converts them.
+// Underscores are not allowed in the names of dogus and components, which keeps the keys unambiguous.
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+// OwnerLabel contains the name of the debugMode owning a state ConfigMap.
+const OwnerLabel = "debugmode.k8s.cloudogu.com/owner"
+
+const keySeparator = "_"
+
+// ConfigMapName returns the name of the state ConfigMap of the given debugMode.
+func ConfigMapName(debugModeName string) string {
+	return fmt.Sprintf("debugmode-%s-state", debugModeName)
+}
+
+// Snapshot contains the original log levels of all targets whose log levels were changed by a debugMode.
+type Snapshot struct {
+	// DebugModeName is the name of the debugMode that changed the log levels.
+	DebugModeName string
+	// DebugModeUID is the UID of the debugMode, which is referenced as owner of the ConfigMap.
+	DebugModeUID types.UID
+	// Namespace is the namespace of the debugMode and the ConfigMap.
+	Namespace string
+	// LogLevels contains the original log level of each target, sorted by their key.
+	LogLevels []v1.LogLevelTarget
+}
+
+// NewSnapshot creates a snapshot of the given original log levels for the debugMode.
+func NewSnapshot(debugMode *v1.DebugMode, originalLogLevels []v1.LogLevelTarget) *Snapshot {
+	logLevels := append([]v1.LogLevelTarget(nil), originalLogLevels...)
+	sortByKey(logLevels)
+
+	return &Snapshot{
+		DebugModeName: debugMode.Name,
+		DebugModeUID:  debugMode.UID,
+		Namespace:     debugMode.Namespace,
+		LogLevels:     logLevels,
+	}
+}
+
+// LogLevelFor returns the original log level of the given target and whether it is contained in the snapshot.
+func (s *Snapshot) LogLevelFor(kind v1.TargetKind, name string) (string, bool) {
+	for _, target := range s.LogLevels {
+		if target.Kind == kind && target.Name == name {
+			return target.LogLevel, true
+		}
+	}
+
+	return "", false
+}
+
+// ToConfigMap builds the state ConfigMap of the snapshot.
+func (s *Snapshot) ToConfigMap() *corev1.ConfigMap {
+	data := make(map[string]string, len(s.LogLevels))
+	for _, target := range s.LogLevels {
+		data[Key(target.Kind, target.Name)] = target.LogLevel
+	}
+
+	isController := true
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(s.DebugModeName),
+			Namespace: s.Namespace,
+			Labels:    map[string]string{OwnerLabel: s.DebugModeName},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         v1.GroupVersion.String(),
+				Kind:               "DebugMode",
+				Name:               s.DebugModeName,
+				UID:                s.DebugModeUID,
+				Controller:         &isController,
+				BlockOwnerDeletion: &isController,
+			}},
+		},
+		Data: data,
+	}
+}
+
+// FromConfigMap parses the snapshot from the given state ConfigMap.
+func FromConfigMap(configMap *corev1.ConfigMap) (*Snapshot, error) {
+	debugModeName, ok := configMap.Labels[OwnerLabel]
+	if !ok {
+		return nil, fmt.Errorf("state configMap %s is missing label %s", configMap.Name, OwnerLabel)
+	}
+
+	snapshot := &Snapshot{DebugModeName: debugModeName, Namespace: configMap.Namespace}
+	for _, ownerReference := range configMap.OwnerReferences {
+		if ownerReference.Kind == "DebugMode" && ownerReference.Name == debugModeName {
+			snapshot.DebugModeUID = ownerReference.UID
+		}
+	}
+
+	for key, logLevel := range configMap.Data {
+		kind, name, err := ParseKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse state configMap %s: %w", configMap.Name, err)
+		}
+
+		snapshot.LogLevels = append(snapshot.LogLevels, v1.LogLevelTarget{Kind: kind, Name: name, LogLevel: logLevel})
+	}
+	sortByKey(snapshot.LogLevels)
+
+	return snapshot, nil
+}
+
+// Key returns the key of the given target in the state ConfigMap, e.g. "dogu_cas".
+func Key(kind v1.TargetKind, name string) string {
+	return string(kind) + keySeparator + name
+}
+
+// ParseKey splits a key of the state ConfigMap like "dogu_cas" into the kind and the name of the target.
+func ParseKey(key string) (v1.TargetKind, string, error) {
+	kind, name, found := strings.Cut(key, keySeparator)
+	if !found || name == "" {
+		return "", "", fmt.Errorf("invalid key %q: expected <kind>%s<name>", key, keySeparator)
+	}
+
+	switch v1.TargetKind(kind) {
+	case v1.TargetKindDogu, v1.TargetKindComponent:
+		return v1.TargetKind(kind), name, nil
+	default:
+		return "", "", fmt.Errorf("invalid key %q: unknown kind %q", key, kind)
+	}
+}
+
+// TargetKey converts a key of the state ConfigMap like "dogu_cas" into the key of the target like "dogu/cas",
+// which is returned by v1.LogLevelTarget.Key and used for v1.ErrorEntry.Target.
+func TargetKey(key string) (string, error) {
+	kind, name, err := ParseKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return v1.LogLevelTarget{Kind: kind, Name: name}.Key(), nil
+}
+
+func sortByKey(targets []v1.LogLevelTarget) {
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Key() < targets[j].Key()
+	})
+}
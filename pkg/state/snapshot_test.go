@@ -0,0 +1,154 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+var testDebugMode = &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem", UID: "my-uid"}}
+
+var testLogLevels = []v1.LogLevelTarget{
+	{Kind: v1.TargetKindDogu, Name: "ldap", LogLevel: v1.LogLevelInfo},
+	{Kind: v1.TargetKindComponent, Name: "k8s-blueprint-operator", LogLevel: v1.LogLevelDebug},
+	{Kind: v1.TargetKindDogu, Name: "cas", LogLevel: v1.LogLevelWarn},
+}
+
+func TestConfigMapName(t *testing.T) {
+	assert.Equal(t, "debugmode-debug-mode-state", ConfigMapName("debug-mode"))
+}
+
+func TestNewSnapshot(t *testing.T) {
+	// when
+	snapshot := NewSnapshot(testDebugMode, testLogLevels)
+
+	// then
+	assert.Equal(t, "debug-mode", snapshot.DebugModeName)
+	assert.Equal(t, "ecosystem", snapshot.Namespace)
+	assert.Equal(t, "my-uid", string(snapshot.DebugModeUID))
+	assert.Equal(t, []v1.LogLevelTarget{
+		{Kind: v1.TargetKindComponent, Name: "k8s-blueprint-operator", LogLevel: v1.LogLevelDebug},
+		{Kind: v1.TargetKindDogu, Name: "cas", LogLevel: v1.LogLevelWarn},
+		{Kind: v1.TargetKindDogu, Name: "ldap", LogLevel: v1.LogLevelInfo},
+	}, snapshot.LogLevels)
+	assert.Equal(t, v1.TargetKindDogu, testLogLevels[0].Kind, "should not sort the given slice")
+}
+
+func TestSnapshot_LogLevelFor(t *testing.T) {
+	snapshot := NewSnapshot(testDebugMode, testLogLevels)
+
+	logLevel, found := snapshot.LogLevelFor(v1.TargetKindDogu, "cas")
+	assert.True(t, found)
+	assert.Equal(t, v1.LogLevelWarn, logLevel)
+
+	_, found = snapshot.LogLevelFor(v1.TargetKindComponent, "cas")
+	assert.False(t, found)
+}
+
+func TestSnapshot_ToConfigMap(t *testing.T) {
+	// when
+	configMap := NewSnapshot(testDebugMode, testLogLevels).ToConfigMap()
+
+	// then
+	assert.Equal(t, "debugmode-debug-mode-state", configMap.Name)
+	assert.Equal(t, "ecosystem", configMap.Namespace)
+	assert.Equal(t, map[string]string{OwnerLabel: "debug-mode"}, configMap.Labels)
+	require.Len(t, configMap.OwnerReferences, 1)
+	ownerReference := configMap.OwnerReferences[0]
+	assert.Equal(t, "k8s.cloudogu.com/v1", ownerReference.APIVersion)
+	assert.Equal(t, "DebugMode", ownerReference.Kind)
+	assert.Equal(t, "debug-mode", ownerReference.Name)
+	assert.Equal(t, "my-uid", string(ownerReference.UID))
+	assert.True(t, *ownerReference.Controller)
+	assert.True(t, *ownerReference.BlockOwnerDeletion)
+	assert.Equal(t, map[string]string{
+		"dogu_ldap":                        v1.LogLevelInfo,
+		"dogu_cas":                         v1.LogLevelWarn,
+		"component_k8s-blueprint-operator": v1.LogLevelDebug,
+	}, configMap.Data)
+}
+
+func TestFromConfigMap(t *testing.T) {
+	t.Run("should parse built config map", func(t *testing.T) {
+		// given
+		expected := NewSnapshot(testDebugMode, testLogLevels)
+
+		// when
+		snapshot, err := FromConfigMap(expected.ToConfigMap())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, expected, snapshot)
+	})
+	t.Run("should fail without owner label", func(t *testing.T) {
+		// given
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-debug-mode-state"}}
+
+		// when
+		_, err := FromConfigMap(configMap)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "state configMap debugmode-debug-mode-state is missing label debugmode.k8s.cloudogu.com/owner")
+	})
+	t.Run("should fail on invalid key", func(t *testing.T) {
+		// given
+		configMap := NewSnapshot(testDebugMode, nil).ToConfigMap()
+		configMap.Data["unknown"] = v1.LogLevelInfo
+
+		// when
+		_, err := FromConfigMap(configMap)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse state configMap debugmode-debug-mode-state: invalid key \"unknown\": expected <kind>_<name>")
+	})
+}
+
+func TestParseKey(t *testing.T) {
+	t.Run("should parse dogu", func(t *testing.T) {
+		kind, name, err := ParseKey("dogu_cas")
+
+		require.NoError(t, err)
+		assert.Equal(t, v1.TargetKindDogu, kind)
+		assert.Equal(t, "cas", name)
+	})
+	t.Run("should parse component", func(t *testing.T) {
+		kind, name, err := ParseKey("component_k8s-blueprint-operator")
+
+		require.NoError(t, err)
+		assert.Equal(t, v1.TargetKindComponent, kind)
+		assert.Equal(t, "k8s-blueprint-operator", name)
+	})
+	t.Run("should fail without name", func(t *testing.T) {
+		_, _, err := ParseKey("dogu_")
+
+		assert.ErrorContains(t, err, "invalid key \"dogu_\": expected <kind>_<name>")
+	})
+	t.Run("should fail on unknown kind", func(t *testing.T) {
+		_, _, err := ParseKey("service_cas")
+
+		assert.ErrorContains(t, err, "invalid key \"service_cas\": unknown kind \"service\"")
+	})
+}
+
+func TestTargetKey(t *testing.T) {
+	t.Run("should convert to key of target", func(t *testing.T) {
+		key, err := TargetKey(Key(v1.TargetKindComponent, "k8s-blueprint-operator"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "component/k8s-blueprint-operator", key)
+		assert.Equal(t, v1.LogLevelTarget{Kind: v1.TargetKindComponent, Name: "k8s-blueprint-operator"}.Key(), key)
+	})
+	t.Run("should fail on invalid key", func(t *testing.T) {
+		_, err := TargetKey("dogu/cas")
+
+		assert.ErrorContains(t, err, "invalid key \"dogu/cas\": expected <kind>_<name>")
+	})
+}
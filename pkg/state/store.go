@@ -0,0 +1,76 @@
+package state
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudogu/retry-lib/retry"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Store persists snapshots as state ConfigMaps.
+type Store struct {
+	configMaps corev1client.ConfigMapInterface
+}
+
+// NewStore creates a store that persists snapshots with the given ConfigMap client.
+// The client must be scoped to the namespace of the debugModes.
+func NewStore(configMaps corev1client.ConfigMapInterface) *Store {
+	return &Store{configMaps: configMaps}
+}
+
+// Save creates the state ConfigMap of the snapshot or replaces its content if it already exists.
+func (s *Store) Save(ctx context.Context, snapshot *Snapshot) error {
+	configMap := snapshot.ToConfigMap()
+
+	_, err := s.configMaps.Create(ctx, configMap, metav1.CreateOptions{})
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create state configMap %s: %w", configMap.Name, err)
+	}
+
+	err = retry.OnConflict(func() error {
+		existing, err := s.configMaps.Get(ctx, configMap.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		existing.Labels = configMap.Labels
+		existing.OwnerReferences = configMap.OwnerReferences
+		existing.Data = configMap.Data
+		_, err = s.configMaps.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update state configMap %s: %w", configMap.Name, err)
+	}
+
+	return nil
+}
+
+// Load reads the snapshot of the given debugMode.
+// The returned error can be checked with apierrors.IsNotFound if no snapshot exists.
+func (s *Store) Load(ctx context.Context, debugModeName string) (*Snapshot, error) {
+	name := ConfigMapName(debugModeName)
+	configMap, err := s.configMaps.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state configMap %s: %w", name, err)
+	}
+
+	return FromConfigMap(configMap)
+}
+
+// Delete removes the snapshot of the given debugMode. A missing snapshot is not treated as error.
+func (s *Store) Delete(ctx context.Context, debugModeName string) error {
+	name := ConfigMapName(debugModeName)
+	err := s.configMaps.Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete state configMap %s: %w", name, err)
+	}
+
+	return nil
+}
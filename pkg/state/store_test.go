@@ -0,0 +1,151 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+var testCtx = context.Background()
+
+func TestStore_Save(t *testing.T) {
+	t.Run("should create config map", func(t *testing.T) {
+		// given
+		clientSet := fake.NewClientset()
+		sut := NewStore(clientSet.CoreV1().ConfigMaps("ecosystem"))
+
+		// when
+		err := sut.Save(testCtx, NewSnapshot(testDebugMode, testLogLevels))
+
+		// then
+		require.NoError(t, err)
+		configMap, err := clientSet.CoreV1().ConfigMaps("ecosystem").Get(testCtx, "debugmode-debug-mode-state", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, v1.LogLevelWarn, configMap.Data["dogu_cas"])
+	})
+	t.Run("should replace content of existing config map", func(t *testing.T) {
+		// given
+		existing := NewSnapshot(testDebugMode, testLogLevels).ToConfigMap()
+		clientSet := fake.NewClientset(existing)
+		sut := NewStore(clientSet.CoreV1().ConfigMaps("ecosystem"))
+
+		// when
+		err := sut.Save(testCtx, NewSnapshot(testDebugMode, []v1.LogLevelTarget{{Kind: v1.TargetKindDogu, Name: "redmine", LogLevel: v1.LogLevelError}}))
+
+		// then
+		require.NoError(t, err)
+		configMap, err := clientSet.CoreV1().ConfigMaps("ecosystem").Get(testCtx, "debugmode-debug-mode-state", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"dogu_redmine": v1.LogLevelError}, configMap.Data)
+	})
+	t.Run("should fail on create error", func(t *testing.T) {
+		// given
+		clientSet := fake.NewClientset()
+		clientSet.PrependReactor("create", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, assert.AnError
+		})
+		sut := NewStore(clientSet.CoreV1().ConfigMaps("ecosystem"))
+
+		// when
+		err := sut.Save(testCtx, NewSnapshot(testDebugMode, testLogLevels))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create state configMap debugmode-debug-mode-state")
+	})
+	t.Run("should fail on update error", func(t *testing.T) {
+		// given
+		clientSet := fake.NewClientset(NewSnapshot(testDebugMode, testLogLevels).ToConfigMap())
+		clientSet.PrependReactor("update", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, assert.AnError
+		})
+		sut := NewStore(clientSet.CoreV1().ConfigMaps("ecosystem"))
+
+		// when
+		err := sut.Save(testCtx, NewSnapshot(testDebugMode, testLogLevels))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to update state configMap debugmode-debug-mode-state")
+	})
+}
+
+func TestStore_Load(t *testing.T) {
+	t.Run("should load snapshot", func(t *testing.T) {
+		// given
+		expected := NewSnapshot(testDebugMode, testLogLevels)
+		sut := NewStore(fake.NewClientset(expected.ToConfigMap()).CoreV1().ConfigMaps("ecosystem"))
+
+		// when
+		snapshot, err := sut.Load(testCtx, "debug-mode")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, expected, snapshot)
+	})
+	t.Run("should return not found error", func(t *testing.T) {
+		// given
+		sut := NewStore(fake.NewClientset().CoreV1().ConfigMaps("ecosystem"))
+
+		// when
+		_, err := sut.Load(testCtx, "debug-mode")
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsNotFound(err))
+		assert.ErrorContains(t, err, "failed to get state configMap debugmode-debug-mode-state")
+	})
+}
+
+func TestStore_Delete(t *testing.T) {
+	t.Run("should delete snapshot", func(t *testing.T) {
+		// given
+		clientSet := fake.NewClientset(NewSnapshot(testDebugMode, testLogLevels).ToConfigMap())
+		sut := NewStore(clientSet.CoreV1().ConfigMaps("ecosystem"))
+
+		// when
+		err := sut.Delete(testCtx, "debug-mode")
+
+		// then
+		require.NoError(t, err)
+		_, err = clientSet.CoreV1().ConfigMaps("ecosystem").Get(testCtx, "debugmode-debug-mode-state", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+	t.Run("should ignore missing snapshot", func(t *testing.T) {
+		// given
+		sut := NewStore(fake.NewClientset().CoreV1().ConfigMaps("ecosystem"))
+
+		// when
+		err := sut.Delete(testCtx, "debug-mode")
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail on delete error", func(t *testing.T) {
+		// given
+		clientSet := fake.NewClientset()
+		clientSet.PrependReactor("delete", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, assert.AnError
+		})
+		sut := NewStore(clientSet.CoreV1().ConfigMaps("ecosystem"))
+
+		// when
+		err := sut.Delete(testCtx, "debug-mode")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+const (
+	// DefaultLogLevel is the log level set for debugModes that define neither a TargetLogLevel nor Targets.
+	DefaultLogLevel = v1.LogLevelDebug
+	// DefaultDebugWindow is the time a debugMode stays active if it defines neither a DeactivateTimestamp nor a Duration.
+	DefaultDebugWindow = time.Hour
+)
+
+// +kubebuilder:webhook:path=/mutate-k8s-cloudogu-com-v1-debugmode,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8s.cloudogu.com,resources=debugmodes,verbs=create,versions=v1,name=mdebugmode.k8s.cloudogu.com,admissionReviewVersions=v1
+
+// DebugModeDefaulter fills in defaults for omitted fields of debugModes when they are created.
+type DebugModeDefaulter struct {
+	defaultLogLevel    string
+	defaultDebugWindow time.Duration
+	now                func() time.Time
+}
+
+var _ admission.CustomDefaulter = &DebugModeDefaulter{}
+
+// DefaulterOption configures a DebugModeDefaulter.
+type DefaulterOption func(*DebugModeDefaulter)
+
+// WithDefaultLogLevel sets the log level used if a debugMode omits the TargetLogLevel and the Targets.
+func WithDefaultLogLevel(logLevel string) DefaulterOption {
+	return func(defaulter *DebugModeDefaulter) {
+		defaulter.defaultLogLevel = logLevel
+	}
+}
+
+// WithDefaultDebugWindow sets how long a debugMode stays active if it omits the DeactivateTimestamp and the Duration.
+func WithDefaultDebugWindow(debugWindow time.Duration) DefaulterOption {
+	return func(defaulter *DebugModeDefaulter) {
+		defaulter.defaultDebugWindow = debugWindow
+	}
+}
+
+// NewDebugModeDefaulter creates a new defaulter for debugModes using DefaultLogLevel and DefaultDebugWindow
+// unless configured otherwise.
+func NewDebugModeDefaulter(opts ...DefaulterOption) *DebugModeDefaulter {
+	defaulter := &DebugModeDefaulter{
+		defaultLogLevel:    DefaultLogLevel,
+		defaultDebugWindow: DefaultDebugWindow,
+		now:                time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(defaulter)
+	}
+
+	return defaulter
+}
+
+// SetupWebhookWithManager registers the defaulter as mutating webhook for debugModes at the given manager.
+func (defaulter *DebugModeDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1.DebugMode{}).
+		WithDefaulter(defaulter).
+		Complete()
+}
+
+// Default sets the TargetLogLevel and the DeactivateTimestamp of the debugMode if they are omitted.
+// The TargetLogLevel is only set if no Targets are given, so a debugMode can change the log level of single dogus
+// or components without changing all others. The DeactivateTimestamp is only set if no Duration is given either.
+// Updates are not defaulted, so changing e.g. the finalizers of a debugMode does not extend or alter its spec.
+func (defaulter *DebugModeDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	debugMode, err := toDebugMode(obj)
+	if err != nil {
+		return err
+	}
+
+	if request, err := admission.RequestFromContext(ctx); err == nil && request.Operation != admissionv1.Create {
+		return nil
+	}
+
+	if debugMode.Spec.TargetLogLevel == "" && len(debugMode.Spec.Targets) == 0 {
+		debugMode.Spec.TargetLogLevel = defaulter.defaultLogLevel
+	}
+
+	if debugMode.Spec.DeactivateTimestamp.IsZero() && debugMode.Spec.Duration == nil {
+		debugMode.Spec.DeactivateTimestamp = metav1.NewTime(defaulter.now().Add(defaulter.defaultDebugWindow))
+	}
+
+	return nil
+}
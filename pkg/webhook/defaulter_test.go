@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+func newTestDefaulter(opts ...DefaulterOption) *DebugModeDefaulter {
+	defaulter := NewDebugModeDefaulter(opts...)
+	defaulter.now = func() time.Time { return testNow }
+	return defaulter
+}
+
+func TestDebugModeDefaulter_Default(t *testing.T) {
+	t.Run("should set default log level and window", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeSpec{})
+
+		// when
+		err := newTestDefaulter().Default(testCtx, debugMode)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.LogLevelDebug, debugMode.Spec.TargetLogLevel)
+		assert.True(t, testNow.Add(time.Hour).Equal(debugMode.Spec.DeactivateTimestamp.Time))
+	})
+	t.Run("should set configured defaults", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeSpec{})
+
+		// when
+		err := newTestDefaulter(WithDefaultLogLevel(v1.LogLevelInfo), WithDefaultDebugWindow(30*time.Minute)).Default(testCtx, debugMode)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.LogLevelInfo, debugMode.Spec.TargetLogLevel)
+		assert.True(t, testNow.Add(30*time.Minute).Equal(debugMode.Spec.DeactivateTimestamp.Time))
+	})
+	t.Run("should keep given values", func(t *testing.T) {
+		// given
+		deactivateTimestamp := metav1.NewTime(testNow.Add(2 * time.Hour))
+		debugMode := newDebugMode(v1.DebugModeSpec{TargetLogLevel: v1.LogLevelWarn, DeactivateTimestamp: deactivateTimestamp})
+
+		// when
+		err := newTestDefaulter().Default(testCtx, debugMode)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.LogLevelWarn, debugMode.Spec.TargetLogLevel)
+		assert.Equal(t, deactivateTimestamp, debugMode.Spec.DeactivateTimestamp)
+	})
+	t.Run("should not set log level if targets are given", func(t *testing.T) {
+		// given
+		targets := []v1.LogLevelTarget{{Kind: v1.TargetKindDogu, Name: "cas", LogLevel: v1.LogLevelDebug}}
+		debugMode := newDebugMode(v1.DebugModeSpec{Targets: targets})
+
+		// when
+		err := newTestDefaulter().Default(testCtx, debugMode)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, debugMode.Spec.TargetLogLevel)
+		assert.Equal(t, targets, debugMode.Spec.Targets)
+		assert.Empty(t, debugMode.Spec.LogLevelFor(v1.TargetKindDogu, "ldap"))
+	})
+	t.Run("should not set deactivate timestamp if duration is given", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeSpec{Duration: &metav1.Duration{Duration: 2 * time.Hour}})
+
+		// when
+		err := newTestDefaulter().Default(testCtx, debugMode)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, debugMode.Spec.DeactivateTimestamp.IsZero())
+	})
+	t.Run("should set defaults on create", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeSpec{})
+		ctx := admission.NewContextWithRequest(testCtx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create}})
+
+		// when
+		err := newTestDefaulter().Default(ctx, debugMode)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, v1.LogLevelDebug, debugMode.Spec.TargetLogLevel)
+		assert.True(t, testNow.Add(time.Hour).Equal(debugMode.Spec.DeactivateTimestamp.Time))
+	})
+	t.Run("should not set defaults on update", func(t *testing.T) {
+		// given
+		// e.g. a controller adds its finalizer to a debug mode that was created without defaults
+		debugMode := newDebugMode(v1.DebugModeSpec{})
+		debugMode.Finalizers = []string{"my-finalizer"}
+		ctx := admission.NewContextWithRequest(testCtx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Update}})
+
+		// when
+		err := newTestDefaulter().Default(ctx, debugMode)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, debugMode.Spec.TargetLogLevel)
+		assert.True(t, debugMode.Spec.DeactivateTimestamp.IsZero())
+	})
+	t.Run("should fail for other objects", func(t *testing.T) {
+		// when
+		err := newTestDefaulter().Default(testCtx, &corev1.ConfigMap{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "expected a DebugMode but got *v1.ConfigMap")
+	})
+}
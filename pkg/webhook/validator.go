@@ -0,0 +1,184 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+// +kubebuilder:webhook:path=/validate-k8s-cloudogu-com-v1-debugmode,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8s.cloudogu.com,resources=debugmodes,verbs=create;update,versions=v1,name=vdebugmode.k8s.cloudogu.com,admissionReviewVersions=v1
+
+// DebugModeValidator validates debugModes on admission.
+type DebugModeValidator struct {
+	allowedLogLevels []string
+	maxDebugWindow   time.Duration
+	now              func() time.Time
+}
+
+var _ admission.CustomValidator = &DebugModeValidator{}
+
+// ValidatorOption configures a DebugModeValidator.
+type ValidatorOption func(*DebugModeValidator)
+
+// WithAllowedLogLevels sets the log levels that are accepted for the debugMode and its targets.
+// By default, all levels of v1.LogLevels are accepted.
+func WithAllowedLogLevels(logLevels ...string) ValidatorOption {
+	return func(validator *DebugModeValidator) {
+		validator.allowedLogLevels = logLevels
+	}
+}
+
+// WithMaxDebugWindow sets how far in the future the deactivation of a debugMode may be.
+// By default, the debug window is not limited.
+func WithMaxDebugWindow(maxDebugWindow time.Duration) ValidatorOption {
+	return func(validator *DebugModeValidator) {
+		validator.maxDebugWindow = maxDebugWindow
+	}
+}
+
+// NewDebugModeValidator creates a new validator for debugModes.
+func NewDebugModeValidator(opts ...ValidatorOption) *DebugModeValidator {
+	validator := &DebugModeValidator{
+		allowedLogLevels: v1.LogLevels,
+		now:              time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(validator)
+	}
+
+	return validator
+}
+
+// SetupWebhookWithManager registers the validator as validating webhook for debugModes at the given manager.
+func (validator *DebugModeValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1.DebugMode{}).
+		WithValidator(validator).
+		Complete()
+}
+
+// ValidateCreate validates the log levels and the deactivation time of the new debugMode.
+func (validator *DebugModeValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	debugMode, err := toDebugMode(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	allErrs := validator.validateLogLevels(debugMode)
+	allErrs = append(allErrs, validator.validateDeactivation(debugMode)...)
+
+	return nil, toInvalidError(debugMode, allErrs)
+}
+
+// ValidateUpdate validates the changed spec of the debugMode.
+// The spec must not change while the debugMode is rolled back.
+func (validator *DebugModeValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldDebugMode, err := toDebugMode(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	newDebugMode, err := toDebugMode(newObj)
+	if err != nil {
+		return nil, err
+	}
+
+	// updates that leave the spec untouched, e.g. removing a finalizer of an expired debugMode, must always pass
+	if apiequality.Semantic.DeepEqual(oldDebugMode.Spec, newDebugMode.Spec) {
+		return nil, nil
+	}
+
+	var allErrs field.ErrorList
+	if oldDebugMode.Status.Phase == v1.DebugModeStatusRollback {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"),
+			fmt.Sprintf("spec must not be changed while the debugMode is in phase %q", v1.DebugModeStatusRollback)))
+	}
+	allErrs = append(allErrs, validator.validateLogLevels(newDebugMode)...)
+	allErrs = append(allErrs, validator.validateDeactivation(newDebugMode)...)
+
+	return nil, toInvalidError(newDebugMode, allErrs)
+}
+
+// ValidateDelete accepts every deletion.
+func (validator *DebugModeValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (validator *DebugModeValidator) validateLogLevels(debugMode *v1.DebugMode) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	// an empty level is left to the defaulting webhook or the consumer
+	if debugMode.Spec.TargetLogLevel != "" && !slices.Contains(validator.allowedLogLevels, debugMode.Spec.TargetLogLevel) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("targetLogLevel"), debugMode.Spec.TargetLogLevel, validator.allowedLogLevels))
+	}
+
+	for i, target := range debugMode.Spec.Targets {
+		if !slices.Contains(validator.allowedLogLevels, target.LogLevel) {
+			allErrs = append(allErrs, field.NotSupported(specPath.Child("targets").Index(i).Child("logLevel"), target.LogLevel, validator.allowedLogLevels))
+		}
+	}
+
+	return allErrs
+}
+
+func (validator *DebugModeValidator) validateDeactivation(debugMode *v1.DebugMode) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	now := validator.now()
+
+	if debugMode.Spec.Duration != nil && debugMode.Spec.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("duration"), debugMode.Spec.Duration.Duration.String(), "must be greater than zero"))
+		return allErrs
+	}
+
+	deactivationTime := debugMode.EffectiveDeactivationTime()
+	if deactivationTime.IsZero() && debugMode.Spec.Duration != nil {
+		// the creation timestamp is not yet set on creation, so the duration starts now
+		deactivationTime.Time = now.Add(debugMode.Spec.Duration.Duration)
+	}
+	if deactivationTime.IsZero() {
+		return allErrs
+	}
+
+	deactivationPath := specPath.Child("deactivateTimestamp")
+	if debugMode.Spec.DeactivateTimestamp.IsZero() {
+		deactivationPath = specPath.Child("duration")
+	}
+
+	if !deactivationTime.After(now) {
+		allErrs = append(allErrs, field.Invalid(deactivationPath, deactivationTime.UTC().Format(time.RFC3339), v1.DeactivationTimeNotInFutureMessage))
+	} else if validator.maxDebugWindow > 0 && deactivationTime.Sub(now) > validator.maxDebugWindow {
+		allErrs = append(allErrs, field.Invalid(deactivationPath, deactivationTime.UTC().Format(time.RFC3339),
+			fmt.Sprintf("deactivation time must not be more than %s in the future", validator.maxDebugWindow)))
+	}
+
+	return allErrs
+}
+
+func toDebugMode(obj runtime.Object) (*v1.DebugMode, error) {
+	debugMode, ok := obj.(*v1.DebugMode)
+	if !ok {
+		return nil, fmt.Errorf("expected a DebugMode but got %T", obj)
+	}
+
+	return debugMode, nil
+}
+
+func toInvalidError(debugMode *v1.DebugMode, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(v1.GroupVersion.WithKind("DebugMode").GroupKind(), debugMode.Name, allErrs)
+}
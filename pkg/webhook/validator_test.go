@@ -0,0 +1,186 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+var testCtx = context.Background()
+
+var testNow = time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestValidator(opts ...ValidatorOption) *DebugModeValidator {
+	validator := NewDebugModeValidator(opts...)
+	validator.now = func() time.Time { return testNow }
+	return validator
+}
+
+func newDebugMode(spec v1.DebugModeSpec) *v1.DebugMode {
+	return &v1.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem"}, Spec: spec}
+}
+
+func TestDebugModeValidator_ValidateCreate(t *testing.T) {
+	inOneHour := metav1.NewTime(testNow.Add(time.Hour))
+
+	t.Run("should accept valid debug mode", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeSpec{
+			TargetLogLevel:      v1.LogLevelDebug,
+			DeactivateTimestamp: inOneHour,
+			Targets:             []v1.LogLevelTarget{{Kind: v1.TargetKindDogu, Name: "cas", LogLevel: v1.LogLevelWarn}},
+		})
+
+		// when
+		warnings, err := newTestValidator().ValidateCreate(testCtx, debugMode)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+	t.Run("should reject unknown log levels", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeSpec{
+			TargetLogLevel:      "VERBOSE",
+			DeactivateTimestamp: inOneHour,
+			Targets:             []v1.LogLevelTarget{{Kind: v1.TargetKindDogu, Name: "cas", LogLevel: "TRACE"}},
+		})
+
+		// when
+		_, err := newTestValidator().ValidateCreate(testCtx, debugMode)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.ErrorContains(t, err, "spec.targetLogLevel: Unsupported value: \"VERBOSE\"")
+		assert.ErrorContains(t, err, "spec.targets[0].logLevel: Unsupported value: \"TRACE\"")
+	})
+	t.Run("should only accept configured log levels", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeSpec{TargetLogLevel: v1.LogLevelDebug, DeactivateTimestamp: inOneHour})
+
+		// when
+		_, err := newTestValidator(WithAllowedLogLevels(v1.LogLevelInfo)).ValidateCreate(testCtx, debugMode)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "spec.targetLogLevel: Unsupported value: \"DEBUG\"")
+	})
+	t.Run("should reject deactivation time in the past", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeSpec{TargetLogLevel: v1.LogLevelDebug, DeactivateTimestamp: metav1.NewTime(testNow.Add(-time.Minute))})
+
+		// when
+		_, err := newTestValidator().ValidateCreate(testCtx, debugMode)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "spec.deactivateTimestamp: Invalid value: \"2025-09-01T11:59:00Z\": "+v1.DeactivationTimeNotInFutureMessage)
+	})
+	t.Run("should reject deactivation time beyond maximum debug window", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeSpec{TargetLogLevel: v1.LogLevelDebug, Duration: &metav1.Duration{Duration: 48 * time.Hour}})
+
+		// when
+		_, err := newTestValidator(WithMaxDebugWindow(24*time.Hour)).ValidateCreate(testCtx, debugMode)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "spec.duration: Invalid value: \"2025-09-03T12:00:00Z\": deactivation time must not be more than 24h0m0s in the future")
+	})
+	t.Run("should reject non-positive duration", func(t *testing.T) {
+		// given
+		debugMode := newDebugMode(v1.DebugModeSpec{Duration: &metav1.Duration{}})
+
+		// when
+		_, err := newTestValidator().ValidateCreate(testCtx, debugMode)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "spec.duration: Invalid value: \"0s\": must be greater than zero")
+	})
+	t.Run("should fail for other objects", func(t *testing.T) {
+		// when
+		_, err := newTestValidator().ValidateCreate(testCtx, &corev1.ConfigMap{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "expected a DebugMode but got *v1.ConfigMap")
+	})
+}
+
+func TestDebugModeValidator_ValidateUpdate(t *testing.T) {
+	inOneHour := metav1.NewTime(testNow.Add(time.Hour))
+
+	t.Run("should accept changed spec", func(t *testing.T) {
+		// given
+		oldDebugMode := newDebugMode(v1.DebugModeSpec{TargetLogLevel: v1.LogLevelDebug, DeactivateTimestamp: inOneHour})
+		newDebugMode := oldDebugMode.DeepCopy()
+		newDebugMode.Spec.TargetLogLevel = v1.LogLevelInfo
+
+		// when
+		_, err := newTestValidator().ValidateUpdate(testCtx, oldDebugMode, newDebugMode)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should accept unchanged spec of expired debug mode", func(t *testing.T) {
+		// given
+		oldDebugMode := newDebugMode(v1.DebugModeSpec{TargetLogLevel: v1.LogLevelDebug, DeactivateTimestamp: metav1.NewTime(testNow.Add(-time.Hour))})
+		oldDebugMode.Finalizers = []string{"debugmode-finalizer"}
+		newDebugMode := oldDebugMode.DeepCopy()
+		newDebugMode.Finalizers = nil
+
+		// when
+		_, err := newTestValidator().ValidateUpdate(testCtx, oldDebugMode, newDebugMode)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should reject changed spec during rollback", func(t *testing.T) {
+		// given
+		oldDebugMode := newDebugMode(v1.DebugModeSpec{TargetLogLevel: v1.LogLevelDebug, DeactivateTimestamp: inOneHour})
+		oldDebugMode.Status.Phase = v1.DebugModeStatusRollback
+		newDebugMode := oldDebugMode.DeepCopy()
+		newDebugMode.Spec.TargetLogLevel = v1.LogLevelInfo
+
+		// when
+		_, err := newTestValidator().ValidateUpdate(testCtx, oldDebugMode, newDebugMode)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.ErrorContains(t, err, "spec: Forbidden: spec must not be changed while the debugMode is in phase \"Rollback\"")
+	})
+	t.Run("should validate changed spec", func(t *testing.T) {
+		// given
+		oldDebugMode := newDebugMode(v1.DebugModeSpec{TargetLogLevel: v1.LogLevelDebug, DeactivateTimestamp: inOneHour})
+		newDebugMode := oldDebugMode.DeepCopy()
+		newDebugMode.Spec.TargetLogLevel = "VERBOSE"
+
+		// when
+		_, err := newTestValidator().ValidateUpdate(testCtx, oldDebugMode, newDebugMode)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "spec.targetLogLevel: Unsupported value: \"VERBOSE\"")
+	})
+}
+
+func TestDebugModeValidator_ValidateDelete(t *testing.T) {
+	// when
+	warnings, err := newTestValidator().ValidateDelete(testCtx, newDebugMode(v1.DebugModeSpec{}))
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}